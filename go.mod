@@ -1,6 +1,6 @@
 module github.com/ibrt/golang-inject-logs
 
-go 1.17
+go 1.21
 
 require (
 	github.com/getsentry/sentry-go v0.13.0
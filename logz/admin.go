@@ -0,0 +1,61 @@
+package logz
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+type setLevelsRequest struct {
+	Levels
+	TTLSeconds int `json:"ttlSeconds"`
+}
+
+// NewLevelsHandler returns a http.Handler which allows inspecting and changing levels at runtime:
+//   - GET returns the effective levels;
+//   - PUT replaces the effective levels, optionally reverting them after "ttlSeconds";
+//   - DELETE restores the configured levels.
+//
+// The handler uses the Logs from the request context and should be protected by the caller, it responds with 503 if
+// the request context has no Logs.
+func NewLevelsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logs := Get(r.Context())
+
+		if logs.GetLevels() == nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			// nothing to do here
+		case http.MethodPut:
+			req := &setLevelsRequest{}
+
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if req.TTLSeconds < 0 {
+				http.Error(w, "ttlSeconds must not be negative", http.StatusBadRequest)
+				return
+			}
+			if err := logs.SetLevels(&req.Levels, time.Duration(req.TTLSeconds)*time.Second); err != nil {
+				http.Error(w, errorz.Unwrap(err).Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			logs.ResetLevels()
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(logs.GetLevels()) // the client may have disconnected, nothing to do here
+	})
+}
@@ -0,0 +1,146 @@
+package logz_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type AdminSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestAdmin(t *testing.T) {
+	fixturez.RunSuite(t, &AdminSuite{})
+}
+
+func serveLevelsHandler(ctx context.Context, method, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	logz.NewLevelsHandler().ServeHTTP(rec, httptest.NewRequest(method, "/levels", strings.NewReader(body)).WithContext(ctx))
+	return rec
+}
+
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *failingResponseWriter) Write(_ []byte) (int, error) {
+	return 0, errorz.Errorf("write failed")
+}
+
+func (s *AdminSuite) TestLevelsHandler(ctx context.Context, t *testing.T) {
+	ctx, releaser, _ := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Info
+		cfg.SentryLevel = logz.Error
+	})
+	defer releaser()
+
+	rec := serveLevelsHandler(ctx, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"outputLevel":"info","sentryLevel":"error"}`, rec.Body.String())
+
+	rec = serveLevelsHandler(ctx, http.MethodPut, `{"outputLevel":"debug","sentryLevel":"warning","outputLevelOverrides":{"billing":"error"},"ttlSeconds":60}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"outputLevel":"debug","sentryLevel":"warning","outputLevelOverrides":{"billing":"error"}}`, rec.Body.String())
+	require.Equal(t, &logz.Levels{
+		OutputLevel:          logz.Debug,
+		SentryLevel:          logz.Warning,
+		OutputLevelOverrides: map[string]logz.Level{"billing": logz.Error},
+	}, logz.Get(ctx).GetLevels())
+
+	s.Clock.Mock.Add(59 * time.Second)
+	require.Equal(t, logz.Debug, logz.Get(ctx).GetLevels().OutputLevel)
+	s.Clock.Mock.Add(time.Second)
+	require.Equal(t, &logz.Levels{OutputLevel: logz.Info, SentryLevel: logz.Error}, logz.Get(ctx).GetLevels())
+
+	rec = serveLevelsHandler(ctx, http.MethodPut, `{"outputLevel":"debug","sentryLevel":"debug"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	s.Clock.Mock.Add(time.Hour)
+	require.Equal(t, logz.Debug, logz.Get(ctx).GetLevels().OutputLevel)
+
+	rec = serveLevelsHandler(ctx, http.MethodDelete, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"outputLevel":"info","sentryLevel":"error"}`, rec.Body.String())
+}
+
+func (s *AdminSuite) TestLevelsHandlerErrors(ctx context.Context, t *testing.T) {
+	ctx, releaser, _ := setupLogs(ctx)
+	defer releaser()
+
+	rec := serveLevelsHandler(ctx, http.MethodPut, `{`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveLevelsHandler(ctx, http.MethodPut, `{"outputLevel":"unknown","sentryLevel":"debug"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "unknown level: unknown")
+
+	rec = serveLevelsHandler(ctx, http.MethodPut, `{"outputLevel":"","sentryLevel":"debug"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "outputLevel")
+
+	rec = serveLevelsHandler(ctx, http.MethodPut, `{"outputLevel":"debug","sentryLevel":"debug","ttlSeconds":-1}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	fixturez.RequireNotPanics(t, func() {
+		w := &failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		logz.NewLevelsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/levels", nil).WithContext(ctx))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	rec = serveLevelsHandler(context.Background(), http.MethodGet, "")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = serveLevelsHandler(context.Background(), http.MethodPut, `{"outputLevel":"debug","sentryLevel":"debug"}`)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = serveLevelsHandler(ctx, http.MethodPost, "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, "GET, PUT, DELETE", rec.Header().Get("Allow"))
+}
+
+func (s *AdminSuite) TestSetLevels(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Error
+		cfg.SentryLevel = logz.Error
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("before")
+	require.Empty(t, transport.events)
+
+	fixturez.RequireNoError(t, logz.Get(ctx).SetLevels(&logz.Levels{OutputLevel: logz.Debug, SentryLevel: logz.Debug}, time.Minute))
+	logz.Get(ctx).Debug("during")
+	require.Len(t, transport.events, 1)
+
+	s.Clock.Mock.Add(time.Minute)
+	logz.Get(ctx).Debug("after")
+	require.Len(t, transport.events, 1)
+
+	fixturez.RequireNoError(t, logz.Get(ctx).SetLevels(&logz.Levels{OutputLevel: logz.Debug, SentryLevel: logz.Debug}, time.Minute))
+	logz.Get(ctx).ResetLevels()
+	logz.Get(ctx).Debug("reset")
+	require.Len(t, transport.events, 1)
+
+	require.Error(t, logz.Get(ctx).SetLevels(&logz.Levels{}, 0))
+
+	out := c.GetErrString()
+	require.NotContains(t, out, "before")
+	require.Contains(t, out, "during")
+	require.NotContains(t, out, "after")
+	require.NotContains(t, out, "reset")
+}
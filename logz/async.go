@@ -0,0 +1,216 @@
+package logz
+
+import (
+	"sync"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+// FullQueuePolicy describes what happens to events captured while the async queue is full.
+type FullQueuePolicy string
+
+// Known full queue policies.
+const (
+	BlockPolicy          FullQueuePolicy = "block"
+	DropNewestPolicy     FullQueuePolicy = "dropNewest"
+	DropOldestPolicy     FullQueuePolicy = "dropOldest"
+	DropBelowLevelPolicy FullQueuePolicy = "dropBelowLevel"
+)
+
+// AsyncConfig describes the configuration for async mode, where events are output and sent to Sentry by a pool of
+// workers consuming a bounded queue. With DropBelowLevelPolicy, events below DropBelowLevel are dropped when the
+// queue is full, while the others block.
+type AsyncConfig struct {
+	QueueSize       int             `json:"queueSize" validate:"required,min=1"`
+	Workers         int             `json:"workers" validate:"required,min=1"`
+	FullQueuePolicy FullQueuePolicy `json:"fullQueuePolicy" validate:"required,oneof=block dropNewest dropOldest dropBelowLevel"`
+	DropBelowLevel  Level           `json:"dropBelowLevel" validate:"required_if=FullQueuePolicy dropBelowLevel,omitempty,oneof=trace debug info warning error fatal"`
+}
+
+// AsyncStats describes the state of the async queue, including the number of dropped events by level.
+type AsyncStats struct {
+	Queued  int              `json:"queued"`
+	Dropped map[Level]uint64 `json:"dropped"`
+}
+
+// asyncQueue processes events on a pool of workers, its methods are safe to call on a nil asyncQueue.
+type asyncQueue struct {
+	cfg     *AsyncConfig
+	process func(event *sentry.Event)
+	queue   chan *sentry.Event
+	closeM  sync.RWMutex
+	closed  bool
+	m       sync.Mutex
+	pending int
+	idle    []chan struct{}
+	dropped map[Level]uint64
+}
+
+func newAsyncQueue(cfg *AsyncConfig, process func(event *sentry.Event)) *asyncQueue {
+	if cfg == nil {
+		return nil
+	}
+
+	q := &asyncQueue{
+		cfg:     cfg,
+		process: process,
+		queue:   make(chan *sentry.Event, cfg.QueueSize),
+		dropped: map[Level]uint64{},
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+func (q *asyncQueue) work() {
+	for event := range q.queue {
+		q.process(event)
+		q.done()
+	}
+}
+
+// enqueue queues the event according to the full queue policy, returning false if it must be processed synchronously.
+func (q *asyncQueue) enqueue(event *sentry.Event) bool {
+	if q == nil {
+		return false
+	}
+
+	q.closeM.RLock()
+	defer q.closeM.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	q.add()
+
+	select {
+	case q.queue <- event:
+		return true
+	default:
+	}
+
+	switch q.cfg.FullQueuePolicy {
+	case BlockPolicy:
+		q.queue <- event
+	case DropNewestPolicy:
+		q.drop(event)
+	case DropOldestPolicy:
+		for {
+			select {
+			case q.queue <- event:
+				return true
+			default:
+			}
+
+			select {
+			case oldestEvent := <-q.queue:
+				q.drop(oldestEvent)
+			default:
+			}
+		}
+	case DropBelowLevelPolicy:
+		if !levelFromSentry(event.Level).Enabled(q.cfg.DropBelowLevel) {
+			q.drop(event)
+		} else {
+			q.queue <- event
+		}
+	default:
+		panic(errorz.Errorf("unknown full queue policy: %v", errorz.A(q.cfg.FullQueuePolicy), errorz.SkipPackage()))
+	}
+
+	return true
+}
+
+func (q *asyncQueue) add() {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.pending++
+}
+
+func (q *asyncQueue) done() {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.pending--
+
+	if q.pending == 0 {
+		for _, idle := range q.idle {
+			close(idle)
+		}
+		q.idle = nil
+	}
+}
+
+func (q *asyncQueue) drop(event *sentry.Event) {
+	q.m.Lock()
+	q.dropped[levelFromSentry(event.Level)]++
+	q.m.Unlock()
+	q.done()
+}
+
+// flush waits until all queued events are processed or the timeout elapses, returning false on timeout.
+func (q *asyncQueue) flush(timeout time.Duration) bool {
+	if q == nil {
+		return true
+	}
+
+	q.m.Lock()
+	if q.pending == 0 {
+		q.m.Unlock()
+		return true
+	}
+	idle := make(chan struct{})
+	q.idle = append(q.idle, idle)
+	q.m.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-idle:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// close stops the workers once the queued events are processed, events enqueued later are processed synchronously.
+func (q *asyncQueue) close() {
+	if q == nil {
+		return
+	}
+
+	q.closeM.Lock()
+	defer q.closeM.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+}
+
+// stats returns the current AsyncStats, or nil if async mode is disabled.
+func (q *asyncQueue) stats() *AsyncStats {
+	if q == nil {
+		return nil
+	}
+
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	stats := &AsyncStats{
+		Queued:  len(q.queue),
+		Dropped: make(map[Level]uint64, len(q.dropped)),
+	}
+
+	for l, n := range q.dropped {
+		stats.Dropped[l] = n
+	}
+
+	return stats
+}
@@ -0,0 +1,222 @@
+package logz_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type AsyncSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestAsync(t *testing.T) {
+	fixturez.RunSuite(t, &AsyncSuite{})
+}
+
+type blockingTransport struct {
+	*testTransport
+	m        sync.Mutex
+	messages []string
+	started  chan struct{}
+	unblock  chan struct{}
+}
+
+// Flush implements the sentry.Transport interface.
+func (t *blockingTransport) Flush(_ time.Duration) bool {
+	return true
+}
+
+// SendEvent implements the sentry.Transport interface.
+func (t *blockingTransport) SendEvent(event *sentry.Event) {
+	t.started <- struct{}{}
+	<-t.unblock
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.messages = append(t.messages, event.Message)
+}
+
+func (t *blockingTransport) getMessages() []string {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return append([]string{}, t.messages...)
+}
+
+func setupAsyncLogs(ctx context.Context, policy logz.FullQueuePolicy, configure ...func(cfg *logz.Config)) (context.Context, func(), *blockingTransport) {
+	transport := &blockingTransport{
+		started: make(chan struct{}, 100),
+		unblock: make(chan struct{}),
+	}
+
+	ctx, releaser, _ := setupLogs(ctx, append([]func(cfg *logz.Config){
+		func(cfg *logz.Config) {
+			transport.testTransport = cfg.SentryTransport.(*testTransport)
+			cfg.SentryTransport = transport
+			cfg.OutputLevel = logz.Error
+			cfg.Async = &logz.AsyncConfig{
+				QueueSize:       1,
+				Workers:         1,
+				FullQueuePolicy: policy,
+				DropBelowLevel:  logz.Info,
+			}
+		},
+	}, configure...)...)
+
+	return ctx, releaser, transport
+}
+
+func (s *AsyncSuite) TestAsyncConfig(_ context.Context, t *testing.T) {
+	cfg := &logz.AsyncConfig{QueueSize: 1, Workers: 1, FullQueuePolicy: logz.DropBelowLevelPolicy}
+	require.Error(t, (&logz.Config{Async: cfg}).Validate())
+
+	ctx, releaser, _ := setupLogs(context.Background(), func(cfg *logz.Config) {
+		cfg.Async = &logz.AsyncConfig{QueueSize: 1, Workers: 1, FullQueuePolicy: logz.BlockPolicy}
+	})
+	defer releaser()
+	require.Equal(t, &logz.AsyncStats{Dropped: map[logz.Level]uint64{}}, logz.Get(ctx).GetAsyncStats())
+
+	ctx, releaser, _ = setupLogs(context.Background())
+	defer releaser()
+	require.Nil(t, logz.Get(ctx).GetAsyncStats())
+}
+
+func (s *AsyncSuite) TestBlockPolicy(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.BlockPolicy)
+	defer releaser()
+
+	logz.Get(ctx).Info("1")
+	<-transport.started
+	logz.Get(ctx).Info("2")
+	require.Equal(t, 1, logz.Get(ctx).GetAsyncStats().Queued)
+
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		logz.Get(ctx).Info("3")
+	}()
+
+	select {
+	case <-blocked:
+		require.FailNow(t, "should block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(transport.unblock)
+	<-blocked
+	require.True(t, logz.Get(ctx).Flush())
+	require.Equal(t, []string{"1", "2", "3"}, transport.getMessages())
+	require.Empty(t, logz.Get(ctx).GetAsyncStats().Dropped)
+}
+
+func (s *AsyncSuite) TestDropNewestPolicy(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.DropNewestPolicy)
+	defer releaser()
+
+	logz.Get(ctx).Info("1")
+	<-transport.started
+	logz.Get(ctx).Info("2")
+	logz.Get(ctx).Debug("3")
+	require.Equal(t, &logz.AsyncStats{Queued: 1, Dropped: map[logz.Level]uint64{logz.Debug: 1}}, logz.Get(ctx).GetAsyncStats())
+
+	close(transport.unblock)
+	require.True(t, logz.Get(ctx).Flush())
+	require.Equal(t, []string{"1", "2"}, transport.getMessages())
+}
+
+func (s *AsyncSuite) TestDropOldestPolicy(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.DropOldestPolicy)
+	defer releaser()
+
+	logz.Get(ctx).Info("1")
+	<-transport.started
+	logz.Get(ctx).Info("2")
+	logz.Get(ctx).Debug("3")
+	require.Equal(t, &logz.AsyncStats{Queued: 1, Dropped: map[logz.Level]uint64{logz.Info: 1}}, logz.Get(ctx).GetAsyncStats())
+
+	close(transport.unblock)
+	require.True(t, logz.Get(ctx).Flush())
+	require.Equal(t, []string{"1", "3"}, transport.getMessages())
+}
+
+func (s *AsyncSuite) TestDropBelowLevelPolicy(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.DropBelowLevelPolicy)
+	defer releaser()
+
+	logz.Get(ctx).Info("1")
+	<-transport.started
+	logz.Get(ctx).Debug("2")
+	logz.Get(ctx).Debug("3")
+	require.Equal(t, &logz.AsyncStats{Queued: 1, Dropped: map[logz.Level]uint64{logz.Debug: 1}}, logz.Get(ctx).GetAsyncStats())
+
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		logz.Get(ctx).Info("4")
+	}()
+
+	select {
+	case <-blocked:
+		require.FailNow(t, "should block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(transport.unblock)
+	<-blocked
+	require.True(t, logz.Get(ctx).Flush())
+	require.Equal(t, []string{"1", "2", "4"}, transport.getMessages())
+}
+
+func (s *AsyncSuite) TestFatal(ctx context.Context, t *testing.T) {
+	var transport *blockingTransport
+	var messages []string
+
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.BlockPolicy, func(cfg *logz.Config) {
+		cfg.ReleaseTimeoutSeconds = 0
+		cfg.ExitFunc = func(_ int) {
+			messages = transport.getMessages()
+		}
+	})
+	defer releaser()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(transport.unblock)
+	}()
+
+	logz.Get(ctx).Fatal(errorz.Errorf("fatal error"))
+	require.Len(t, messages, 1)
+}
+
+func (s *AsyncSuite) TestRelease(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupAsyncLogs(ctx, logz.BlockPolicy, func(cfg *logz.Config) {
+		cfg.ReleaseTimeoutSeconds = 1
+		cfg.Async.QueueSize = 10
+	})
+
+	logz.Get(ctx).Info("1")
+	<-transport.started
+	logz.Get(ctx).Info("2")
+	require.False(t, logz.Get(ctx).Flush())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(transport.unblock)
+	}()
+
+	releaser()
+	require.Equal(t, []string{"1", "2"}, transport.getMessages())
+
+	logz.Get(ctx).Info("3")
+	require.Equal(t, []string{"1", "2", "3"}, transport.getMessages())
+}
@@ -0,0 +1,69 @@
+package logz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+var (
+	_ sentry.Transport = &discardTransport{}
+)
+
+type discardTransport struct {
+}
+
+// Flush implements the sentry.Transport interface.
+func (t *discardTransport) Flush(_ time.Duration) bool {
+	return true
+}
+
+// Configure implements the sentry.Transport interface.
+func (t *discardTransport) Configure(_ sentry.ClientOptions) {
+	// intentionally empty
+}
+
+// SendEvent implements the sentry.Transport interface.
+func (t *discardTransport) SendEvent(_ *sentry.Event) {
+	// intentionally empty
+}
+
+func benchmarkDebug(b *testing.B, configure func(cfg *logz.Config)) {
+	ctx, releaser, _ := setupLogs(context.Background(), func(cfg *logz.Config) {
+		cfg.SentryTransport = &discardTransport{}
+		cfg.OutputLevel = logz.Error
+		configure(cfg)
+	})
+	defer releaser()
+
+	logs := logz.Get(ctx)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		logs.Debug("message: %v", logz.A(i), logz.M("k", "v"))
+	}
+}
+
+func BenchmarkDebugFiltered(b *testing.B) {
+	benchmarkDebug(b, func(cfg *logz.Config) {
+		cfg.SentryLevel = logz.Error
+	})
+}
+
+func BenchmarkDebugStackCaptureOff(b *testing.B) {
+	benchmarkDebug(b, func(cfg *logz.Config) {
+		cfg.StackCapture = logz.StackCaptureOff
+	})
+}
+
+func BenchmarkDebugStackCaptureAlways(b *testing.B) {
+	benchmarkDebug(b, func(cfg *logz.Config) {
+		cfg.StackCapture = logz.StackCaptureAlways
+		cfg.MaxStackDepth = 32
+	})
+}
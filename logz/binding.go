@@ -0,0 +1,75 @@
+package logz
+
+import (
+	"context"
+)
+
+// binding describes options bound to a context, applied before the options given to each entry.
+type binding struct {
+	logger  string
+	options []Option
+}
+
+func withBinding(ctx context.Context, options ...Option) context.Context {
+	b := &binding{}
+
+	if parent := getBinding(ctx); parent != nil {
+		b.logger = parent.logger
+		b.options = append(b.options, parent.options...)
+	}
+	b.options = append(b.options, options...)
+
+	return context.WithValue(ctx, logsBindingContextKey, b)
+}
+
+func withBindingName(ctx context.Context, name string) context.Context {
+	b := &binding{
+		logger: name,
+	}
+
+	if parent := getBinding(ctx); parent != nil {
+		b.logger = joinLoggerNames(parent.logger, name)
+		b.options = parent.options
+	}
+
+	return context.WithValue(ctx, logsBindingContextKey, b)
+}
+
+func getBinding(ctx context.Context) *binding {
+	if b, ok := ctx.Value(logsBindingContextKey).(*binding); ok {
+		return b
+	}
+	return nil
+}
+
+// apply applies the bound options to the given entry, it is safe to call on a nil binding.
+func (b *binding) apply(e *entry) {
+	if b == nil {
+		return
+	}
+
+	e.logger = b.logger
+
+	for _, o := range b.options {
+		o.Apply(e)
+	}
+}
+
+// getLogger returns the bound logger name, it is safe to call on a nil binding.
+func (b *binding) getLogger() string {
+	if b == nil {
+		return ""
+	}
+	return b.logger
+}
+
+// getEntry returns the entry resulting from applying the bound options to an empty entry, it is safe to call on a nil
+// binding.
+func (b *binding) getEntry() *entry {
+	e := &entry{
+		metadata: Metadata{},
+		tags:     map[string]string{},
+	}
+	b.apply(e)
+	return e
+}
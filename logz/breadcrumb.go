@@ -0,0 +1,48 @@
+package logz
+
+import (
+	"github.com/getsentry/sentry-go"
+)
+
+const (
+	breadcrumbType          = "default"
+	breadcrumbCategory      = "log"
+	breadcrumbSpanStatusKey = "status"
+)
+
+var (
+	_ Option = breadcrumbOption(false)
+)
+
+// breadcrumbOption overrides Config.Breadcrumbs for an entry, it is resolved before creating the entry.
+type breadcrumbOption bool
+
+// Apply implements the Option interface.
+func (o breadcrumbOption) Apply(_ *entry) {
+	// intentionally empty
+}
+
+// AsBreadcrumb overrides Config.Breadcrumbs for an entry.
+func AsBreadcrumb(enabled bool) Option {
+	return breadcrumbOption(enabled)
+}
+
+func spanToSentryBreadcrumb(span *sentry.Span) *sentry.Breadcrumb {
+	data := make(map[string]interface{}, len(span.Data)+1)
+	for k, v := range span.Data {
+		data[k] = v
+	}
+
+	if span.Status != sentry.SpanStatusUndefined {
+		data[breadcrumbSpanStatusKey] = span.Status.String()
+	}
+
+	return &sentry.Breadcrumb{
+		Type:      breadcrumbType,
+		Category:  span.Op,
+		Message:   span.Description,
+		Data:      data,
+		Level:     sentry.LevelInfo,
+		Timestamp: span.EndTime,
+	}
+}
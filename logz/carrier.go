@@ -0,0 +1,61 @@
+package logz
+
+import (
+	"context"
+)
+
+var (
+	carrierExtractPropagators = []Propagator{SentryPropagator, W3CPropagator}
+)
+
+// Inject writes the trace context of the current span to the given carrier (e.g. message headers), using the
+// configured propagators. Without a current span, it forwards the trace context previously extracted, if any.
+func Inject(ctx context.Context, carrier map[string]string) {
+	tc := getPropagatedTraceContext(ctx)
+	if span := getSpan(ctx); span != nil {
+		tc = newSpanTraceContext(ctx, span)
+	}
+
+	injectTraceContext(getPropagators(ctx), mapCarrier(carrier), tc)
+}
+
+// Extract reads the trace context from the given carrier (e.g. message headers), using the configured propagators
+// as well as the Sentry and W3C formats. The next span or transaction started with the returned context continues
+// the upstream trace, rather than the current one.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	tc := extractTraceContext(getExtractPropagators(ctx), mapCarrier(carrier))
+	if !tc.isValid {
+		return tc.toContext(ctx)
+	}
+
+	return context.WithValue(&rootContext{Context: ctx}, logsPropagationContextKey, tc)
+}
+
+// getPropagatedTraceContext returns the trace context propagated on the context, which is only valid if it was
+// extracted or detached and no span has been started since.
+func getPropagatedTraceContext(ctx context.Context) *traceContext {
+	if tc, ok := ctx.Value(logsPropagationContextKey).(*traceContext); ok {
+		return tc
+	}
+	return &traceContext{}
+}
+
+func getExtractPropagators(ctx context.Context) []Propagator {
+	propagators := getPropagators(ctx)
+	propagators = propagators[:len(propagators):len(propagators)]
+
+	for _, cp := range carrierExtractPropagators {
+		found := false
+		for _, p := range propagators {
+			if p == cp {
+				found = true
+				break
+			}
+		}
+		if !found {
+			propagators = append(propagators, cp)
+		}
+	}
+
+	return propagators
+}
@@ -0,0 +1,126 @@
+package logz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type CarrierSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestCarrier(t *testing.T) {
+	fixturez.RunSuite(t, &CarrierSuite{})
+}
+
+func (s *CarrierSuite) TestInjectExtract(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	carrier := map[string]string{}
+	var producerSpan *sentry.Span
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("queue.publish", "billing")
+		defer releaseSpan()
+		producerSpan = sentry.TransactionFromContext(ctx)
+		logz.Inject(ctx, carrier)
+	}()
+
+	require.Equal(t, map[string]string{
+		"sentry-trace": producerSpan.TraceID.String() + "-" + producerSpan.SpanID.String() + "-1",
+	}, carrier)
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "unrelated")
+		defer releaseSpan()
+
+		ctx = logz.Extract(ctx, carrier)
+		require.Nil(t, sentry.TransactionFromContext(ctx))
+
+		forwarded := map[string]string{}
+		logz.Inject(ctx, forwarded)
+		require.Equal(t, carrier, forwarded)
+
+		ctx, releaseConsumerSpan := logz.Get(ctx).TraceSpan("queue.process", "billing")
+		defer releaseConsumerSpan()
+
+		_, finish := logz.Get(ctx).TraceTransaction("queue.process", "billing")
+		finish(nil)
+	}()
+
+	require.Len(t, transport.events, 4)
+
+	for _, event := range transport.events[1:3] {
+		require.Equal(t, "transaction", event.Type)
+		traceContext := event.Contexts["trace"].(*sentry.TraceContext)
+		require.Equal(t, producerSpan.TraceID, traceContext.TraceID)
+		require.Equal(t, producerSpan.SpanID, traceContext.ParentSpanID)
+	}
+
+	require.Equal(t, "unrelated", transport.events[3].Contexts["trace"].(*sentry.TraceContext).Description)
+	require.NotEqual(t, producerSpan.TraceID, transport.events[3].Contexts["trace"].(*sentry.TraceContext).TraceID)
+	require.Empty(t, transport.events[3].Spans)
+}
+
+func (s *CarrierSuite) TestExtractW3C(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	ctx = logz.Extract(ctx, map[string]string{
+		"Traceparent": "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+		"baggage":     "k=v",
+	})
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("queue.process", "billing")
+		defer releaseSpan()
+
+		carrier := map[string]string{}
+		logz.Inject(ctx, carrier)
+		require.Equal(t, "0123456789abcdef0123456789abcdef", carrier["sentry-trace"][:32])
+		require.NotContains(t, carrier, "baggage")
+	}()
+
+	require.Len(t, transport.events, 1)
+	traceContext := transport.events[0].Contexts["trace"].(*sentry.TraceContext)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", traceContext.TraceID.String())
+	require.Equal(t, "0123456789abcdef", traceContext.ParentSpanID.String())
+}
+
+func (s *CarrierSuite) TestExtractInvalid(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "parent")
+		defer releaseSpan()
+
+		ctx = logz.Extract(ctx, map[string]string{"sentry-trace": "bad"})
+		_, releaseChildSpan := logz.Get(ctx).TraceSpan("op", "child")
+		releaseChildSpan()
+	}()
+
+	require.Len(t, transport.events, 1)
+	require.Len(t, transport.events[0].Spans, 1)
+}
+
+func (s *CarrierSuite) TestInjectExtractNoop(_ context.Context, t *testing.T) {
+	carrier := map[string]string{}
+	logz.Inject(context.Background(), carrier)
+	require.Empty(t, carrier)
+
+	ctx := logz.Extract(context.Background(), map[string]string{
+		"sentry-trace": "0123456789abcdef0123456789abcdef-0123456789abcdef-1",
+	})
+	logz.Inject(ctx, carrier)
+	require.Equal(t, map[string]string{"sentry-trace": "0123456789abcdef0123456789abcdef-0123456789abcdef-1"}, carrier)
+}
@@ -3,7 +3,6 @@ package logz
 import (
 	"context"
 	"fmt"
-	"runtime"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -14,6 +13,8 @@ var (
 	_ Option = OptionFunc(nil)
 	_ Option = &Args{}
 	_ Option = &Metadata{}
+	_ Option = LazyArgs(nil)
+	_ Option = LazyMetadata(nil)
 )
 
 // Option describes an option which can be applied to an entry.
@@ -59,12 +60,43 @@ func M(k string, v interface{}) OptionFunc {
 	}
 }
 
+// LazyArgs describes a list of args used for formatting an entry message, computed only if the entry is emitted.
+type LazyArgs func() Args
+
+// Apply implements the Option interface.
+func (f LazyArgs) Apply(_ *entry) {
+	// intentionally empty
+}
+
+// LazyA is a shorthand builder for lazy args, f is only called if the entry is emitted (see ContextLogs.Enabled).
+func LazyA(f func() Args) LazyArgs {
+	return f
+}
+
+// LazyMetadata describes metadata which can be attached to an entry, computed only if the entry is emitted.
+type LazyMetadata func() Metadata
+
+// Apply implements the Option interface.
+func (f LazyMetadata) Apply(e *entry) {
+	f().Apply(e)
+}
+
+// Lazy is a shorthand for providing lazy metadata to an entry, f is only called if the entry is emitted (see
+// ContextLogs.Enabled).
+func Lazy(f func() Metadata) LazyMetadata {
+	return f
+}
+
 type entry struct {
-	level     Level
-	timestamp time.Time
-	callers   []uintptr
-	message   string
-	metadata  Metadata
+	logger      string
+	level       Level
+	timestamp   time.Time
+	callers     []uintptr
+	callersBuf  *[]uintptr
+	message     string
+	metadata    Metadata
+	tags        map[string]string
+	fingerprint []string
 }
 
 func (e *entry) toSentryEvent() *sentry.Event {
@@ -73,38 +105,113 @@ func (e *entry) toSentryEvent() *sentry.Event {
 	event.Timestamp = e.timestamp
 	event.Message = e.message
 	event.Extra = e.metadata
+	event.Fingerprint = e.fingerprint
 
-	event.Threads = []sentry.Thread{{
-		Stacktrace: callersToSentryStacktrace(e.callers),
-		Crashed:    false,
-		Current:    true,
-	}}
+	for k, v := range e.tags {
+		event.Tags[k] = v
+	}
+
+	if e.logger != "" {
+		event.Tags[loggerTagKey] = e.logger
+	}
+
+	if len(e.callers) > 0 {
+		event.Threads = []sentry.Thread{{
+			Stacktrace: callersToSentryStacktrace(e.callers),
+			Crashed:    false,
+			Current:    true,
+		}}
+	}
 
 	return event
 }
 
-func newEntry(ctx context.Context, level Level, skipCallers int, format string, options ...Option) *entry {
-	callers := make([]uintptr, 1024)
-	callers = callers[:runtime.Callers(2+skipCallers, callers[:])]
+func (e *entry) toSentryBreadcrumb() *sentry.Breadcrumb {
+	category := breadcrumbCategory
+	if e.logger != "" {
+		category = e.logger
+	}
 
-	var mergedArgs []interface{}
-	for _, option := range options {
-		if args, ok := option.(Args); ok {
-			mergedArgs = append(mergedArgs, args...)
-		}
+	return &sentry.Breadcrumb{
+		Type:      breadcrumbType,
+		Category:  category,
+		Message:   e.message,
+		Data:      e.metadata,
+		Level:     e.level.toSentry(),
+		Timestamp: e.timestamp,
 	}
+}
 
+// newEntry creates an entry, capturing up to maxStackDepth callers (none if zero). Entries must be released once
+// converted to Sentry events.
+func newEntry(ctx context.Context, level Level, skipCallers, maxStackDepth int, format string, options ...Option) *entry {
 	e := &entry{
 		level:     level,
 		timestamp: clockz.Get(ctx).Now(),
-		callers:   callers,
-		message:   fmt.Sprintf(format, mergedArgs...),
 		metadata:  Metadata{},
+		tags:      map[string]string{},
+	}
+
+	if maxStackDepth > 0 {
+		e.callersBuf, e.callers = captureCallers(skipCallers+1, maxStackDepth)
 	}
 
+	var mergedArgs []interface{}
+	for _, option := range options {
+		switch args := option.(type) {
+		case Args:
+			mergedArgs = append(mergedArgs, args...)
+		case LazyArgs:
+			mergedArgs = append(mergedArgs, args()...)
+		}
+	}
+
+	e.message = fmt.Sprintf(format, mergedArgs...)
+
+	getBinding(ctx).apply(e)
+
 	for _, o := range options {
 		o.Apply(e)
 	}
 
 	return e
 }
+
+// release returns the callers buffer to the pool, the callers must not be used afterwards.
+func (e *entry) release() {
+	releaseCallers(e.callersBuf)
+	e.callersBuf = nil
+	e.callers = nil
+}
+
+// getOptionsLevel returns the level resulting from applying the given options, without applying them.
+func getOptionsLevel(level Level, options []Option) Level {
+	for _, o := range options {
+		if l, ok := o.(levelOption); ok {
+			level = Level(l)
+		}
+	}
+	return level
+}
+
+// getOptionsBreadcrumb returns whether the entry resulting from applying the bound and given options would be recorded
+// as a breadcrumb, without applying them.
+func getOptionsBreadcrumb(defaultBreadcrumb bool, b *binding, options []Option) bool {
+	isBreadcrumb := defaultBreadcrumb
+
+	if b != nil {
+		for _, o := range b.options {
+			if bo, ok := o.(breadcrumbOption); ok {
+				isBreadcrumb = bool(bo)
+			}
+		}
+	}
+
+	for _, o := range options {
+		if bo, ok := o.(breadcrumbOption); ok {
+			isBreadcrumb = bool(bo)
+		}
+	}
+
+	return isBreadcrumb
+}
@@ -21,7 +21,7 @@ func TestEntry(t *testing.T) {
 }
 
 func (s *EntrySuite) TestEntry(ctx context.Context, t *testing.T) {
-	e := newEntry(ctx, Debug, 0, "message: %v", A("value"), M("k1", "v1"), Metadata{"k2": "v2"})
+	e := newEntry(ctx, Debug, 0, defaultMaxStackDepth, "message: %v", A("value"), M("k1", "v1"), Metadata{"k2": "v2"})
 	event := e.toSentryEvent()
 	require.Len(t, event.Threads, 1)
 	event.Threads = nil
@@ -35,7 +35,7 @@ func (s *EntrySuite) TestEntry(ctx context.Context, t *testing.T) {
 		Timestamp: clockz.Get(ctx).Now(),
 	}, event)
 
-	e = newEntry(ctx, Warning, 0, "message: %v", A("value"), M("k1", "v1"), Metadata{"k2": "v2"})
+	e = newEntry(ctx, Warning, 0, defaultMaxStackDepth, "message: %v", A("value"), M("k1", "v1"), Metadata{"k2": "v2"})
 	event = e.toSentryEvent()
 	require.Len(t, event.Threads, 1)
 	event.Threads = nil
@@ -49,3 +49,21 @@ func (s *EntrySuite) TestEntry(ctx context.Context, t *testing.T) {
 		Timestamp: clockz.Get(ctx).Now(),
 	}, event)
 }
+
+func (s *EntrySuite) TestEntryBinding(ctx context.Context, t *testing.T) {
+	ctx = withBinding(ctx, M("k1", "b1"), M("k2", "b2"))
+	ctx = withBinding(ctx, M("k3", "b3"))
+
+	e := newEntry(ctx, Info, 0, 0, "message", M("k1", "v1"))
+	require.Equal(t, Metadata{"k1": "v1", "k2": "b2", "k3": "b3"}, e.metadata)
+	require.Equal(t, Metadata{"k1": "b1", "k2": "b2", "k3": "b3"}, getBinding(ctx).getEntry().metadata)
+	require.Nil(t, getBinding(context.Background()))
+
+	ctx = withBindingName(withBindingName(ctx, "a"), "b")
+	e = newEntry(ctx, Info, 0, 0, "message")
+	require.Equal(t, "a.b", e.logger)
+	require.Equal(t, Metadata{"k1": "b1", "k2": "b2", "k3": "b3"}, e.metadata)
+	require.Equal(t, map[string]string{"logger": "a.b"}, e.toSentryEvent().Tags)
+	require.Equal(t, "a.b", getBinding(withBinding(ctx, M("k", "v"))).getEntry().logger)
+	require.Equal(t, "", getBinding(context.Background()).getEntry().logger)
+}
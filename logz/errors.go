@@ -23,12 +23,34 @@ func errorToSentryEvent(ctx context.Context, err error, level Level) *sentry.Eve
 	event.Timestamp = clockz.Get(ctx).Now()
 	event.Level = level.toSentry()
 
+	bound := getBinding(ctx).getEntry()
+	event.Fingerprint = bound.fingerprint
+
+	for k, v := range bound.metadata {
+		event.Extra[k] = v
+	}
+
+	for k, v := range bound.tags {
+		event.Tags[k] = v
+	}
+
+	if bound.logger != "" {
+		event.Tags[loggerTagKey] = bound.logger
+	}
+
 	if status := errorz.GetStatus(err); status != 0 {
 		event.Extra[statusKey] = status.Int()
 	}
 
 	for k, v := range errorz.GetMetadata(err) {
-		event.Extra[k] = v
+		switch v := v.(type) {
+		case fingerprintValue:
+			event.Fingerprint = v
+		case tagValue:
+			event.Tags[k] = string(v)
+		default:
+			event.Extra[k] = v
+		}
 	}
 
 	for i := 0; i < maxErrorDepth && err != nil; i++ {
@@ -0,0 +1,24 @@
+package logz
+
+import (
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	fingerprintKey = "fingerprint"
+)
+
+// fingerprintValue marks error metadata holding a fingerprint, which is moved to the Sentry event.
+type fingerprintValue []string
+
+// Fingerprint sets the fingerprint of an entry, which controls how Sentry groups it into issues.
+func Fingerprint(fingerprint ...string) OptionFunc {
+	return func(e *entry) {
+		e.fingerprint = fingerprint
+	}
+}
+
+// ErrorFingerprint is an errorz.Option which sets the fingerprint used when the error is reported to Sentry.
+func ErrorFingerprint(fingerprint ...string) errorz.OptionFunc {
+	return errorz.M(fingerprintKey, fingerprintValue(fingerprint))
+}
@@ -0,0 +1,99 @@
+package logz
+
+import (
+	"context"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+var (
+	_ context.Context = &detachedContext{}
+)
+
+// forkedSpan wraps the span of a forked context, so that it is used as parent but not modified concurrently.
+type forkedSpan struct {
+	span *sentry.Span
+}
+
+// detachedContext carries the values of its parent without its cancellation, hiding its spans.
+type detachedContext struct {
+	parent context.Context
+}
+
+// Deadline implements the context.Context interface.
+func (c *detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done implements the context.Context interface.
+func (c *detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err implements the context.Context interface.
+func (c *detachedContext) Err() error {
+	return nil
+}
+
+// Value implements the context.Context interface.
+func (c *detachedContext) Value(key interface{}) interface{} {
+	return hideSpan(c.parent.Value(key))
+}
+
+// hideSpan returns nil if the given context value is a span, so that new spans are not started as its children.
+func hideSpan(v interface{}) interface{} {
+	switch v.(type) {
+	case *sentry.Span, forkedSpan:
+		return nil
+	default:
+		return v
+	}
+}
+
+// Fork returns a context for concurrent work, with a cloned hub so that scope changes do not race with the parent.
+// New spans are started as children of the current span, which must outlive them.
+func Fork(ctx context.Context) context.Context {
+	if hub := sentry.GetHubFromContext(ctx); hub != nil {
+		ctx = sentry.SetHubOnContext(ctx, hub.Clone())
+	}
+
+	if span := getSpan(ctx); span != nil {
+		ctx = context.WithValue(ctx, logsSpanContextKey, forkedSpan{span: span})
+	}
+
+	return ctx
+}
+
+// Detach returns a context for fire-and-forget background work, which is not canceled with the parent. It keeps the
+// Logs and a cloned hub (including the user), and new spans start a transaction linked to the current trace.
+func Detach(ctx context.Context) context.Context {
+	tc := &traceContext{}
+	if span := getSpan(ctx); span != nil {
+		tc = newSpanTraceContext(ctx, span)
+	}
+
+	detachedCtx := context.Context(&detachedContext{parent: ctx})
+
+	if hub := sentry.GetHubFromContext(ctx); hub != nil {
+		detachedCtx = sentry.SetHubOnContext(detachedCtx, hub.Clone())
+	}
+
+	if tc.isValid {
+		detachedCtx = context.WithValue(detachedCtx, logsPropagationContextKey, tc)
+	}
+
+	return detachedCtx
+}
+
+// getSpan returns the current span, which is either the innermost span started by TraceSpan or the transaction.
+func getSpan(ctx context.Context) *sentry.Span {
+	switch span := ctx.Value(logsSpanContextKey).(type) {
+	case *sentry.Span:
+		return span
+	case forkedSpan:
+		return span.span
+	default:
+		return sentry.TransactionFromContext(ctx)
+	}
+}
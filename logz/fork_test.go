@@ -0,0 +1,204 @@
+package logz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type ForkSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestFork(t *testing.T) {
+	fixturez.RunSuite(t, &ForkSuite{})
+}
+
+type lockedTransport struct {
+	*testTransport
+	m sync.Mutex
+}
+
+// SendEvent implements the sentry.Transport interface.
+func (t *lockedTransport) SendEvent(event *sentry.Event) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.testTransport.SendEvent(event)
+}
+
+func setupLockedLogs(ctx context.Context) (context.Context, func(), *testTransport) {
+	return setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.SentryTransport = &lockedTransport{testTransport: cfg.SentryTransport.(*testTransport)}
+		cfg.OutputLevel = logz.Error
+	})
+}
+
+func (s *ForkSuite) TestFork(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLockedLogs(ctx)
+	defer releaser()
+
+	var transactionSpan *sentry.Span
+
+	func() {
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(httptest.NewRequest("GET", "/path", nil), nil)
+		defer releaseTransaction()
+		transactionSpan = sentry.TransactionFromContext(ctx)
+		logz.Get(ctx).SetUser(&logz.User{ID: "parent"})
+
+		wg := &sync.WaitGroup{}
+
+		for i := 0; i < 10; i++ {
+			i := i
+			wg.Add(1)
+			forkedCtx := logz.Fork(ctx)
+
+			go func() {
+				defer wg.Done()
+
+				logz.Get(forkedCtx).SetUser(&logz.User{ID: fmt.Sprintf("user-%v", i)})
+				logz.Get(forkedCtx).AddMetadata("k", i)
+				logz.Get(forkedCtx).AddTag("t", fmt.Sprintf("%v", i))
+
+				spanCtx, releaseSpan := logz.Get(forkedCtx).TraceSpan("op", fmt.Sprintf("span-%v", i))
+				logz.Get(spanCtx).AddMetadata("sk", i)
+				releaseSpan()
+
+				logz.Get(forkedCtx).Debug("message %v", logz.A(i))
+			}()
+		}
+
+		for i := 0; i < 10; i++ {
+			logz.Get(ctx).AddMetadata(fmt.Sprintf("parent-%v", i), i)
+		}
+
+		wg.Wait()
+		logz.Get(ctx).Debug("parent message")
+	}()
+
+	require.Len(t, transport.events, 12)
+
+	for _, event := range transport.events[:10] {
+		var i int
+		_, err := fmt.Sscanf(event.Message, "message %d", &i)
+		fixturez.RequireNoError(t, err)
+		require.Equal(t, fmt.Sprintf("user-%v", i), event.User.ID)
+		require.Equal(t, i, event.Extra["k"])
+		require.Equal(t, fmt.Sprintf("%v", i), event.Tags["t"])
+	}
+
+	require.Equal(t, "parent message", transport.events[10].Message)
+	require.Equal(t, "parent", transport.events[10].User.ID)
+	require.NotContains(t, transport.events[10].Extra, "k")
+	require.Equal(t, 9, transport.events[10].Extra["parent-9"])
+
+	transaction := transport.events[11]
+	require.Equal(t, "transaction", transaction.Type)
+	require.Len(t, transaction.Spans, 10)
+	for _, span := range transaction.Spans {
+		require.Equal(t, transactionSpan.TraceID, span.TraceID)
+		require.Equal(t, transactionSpan.SpanID, span.ParentSpanID)
+		require.Contains(t, span.Data, "sk")
+	}
+}
+
+func (s *ForkSuite) TestForkSpan(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLockedLogs(ctx)
+	defer releaser()
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "parent")
+		defer releaseSpan()
+
+		forkedCtx := logz.Fork(ctx)
+		logz.Get(forkedCtx).AddMetadata("k", "v")
+
+		_, releaseChildSpan := logz.Get(forkedCtx).TraceSpan("op", "child")
+		releaseChildSpan()
+	}()
+
+	require.Len(t, transport.events, 1)
+	require.Empty(t, transport.events[0].Extra)
+	require.Len(t, transport.events[0].Spans, 1)
+	require.Equal(t, "child", transport.events[0].Spans[0].Description)
+}
+
+func (s *ForkSuite) TestDetach(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLockedLogs(ctx)
+	defer releaser()
+
+	var transactionSpan *sentry.Span
+	var detachedCtx context.Context
+	wg := &sync.WaitGroup{}
+	started := make(chan struct{})
+
+	func() {
+		cancelCtx, cancel := context.WithTimeout(ctx, time.Hour)
+		defer cancel()
+
+		req := httptest.NewRequest("GET", "/path", nil)
+		req.Header.Set("baggage", "k=v")
+		reqCtx, releaseTransaction := logz.Get(cancelCtx).TraceHTTPRequestServer(req, nil)
+		defer releaseTransaction()
+		transactionSpan = sentry.TransactionFromContext(reqCtx)
+		logz.Get(reqCtx).SetUser(&logz.User{ID: "uid"})
+
+		detachedCtx = logz.Detach(reqCtx)
+		require.Nil(t, sentry.TransactionFromContext(detachedCtx))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-started
+
+			logz.Get(detachedCtx).AddMetadata("k", "v")
+			spanCtx, releaseSpan := logz.Get(detachedCtx).TraceSpan("background", "work")
+			_, releaseChildSpan := logz.Get(spanCtx).TraceSpan("background", "child")
+			releaseChildSpan()
+			releaseSpan()
+		}()
+	}()
+
+	close(started)
+	wg.Wait()
+
+	require.NoError(t, detachedCtx.Err())
+	require.Nil(t, detachedCtx.Done())
+	_, ok := detachedCtx.Deadline()
+	require.False(t, ok)
+
+	require.Len(t, transport.events, 2)
+	require.Equal(t, "GET /path", transport.events[0].Transaction)
+	require.NotContains(t, transport.events[0].Extra, "k")
+
+	background := transport.events[1]
+	require.Equal(t, "transaction", background.Type)
+	require.Equal(t, "uid", background.User.ID)
+	require.Equal(t, "v", background.Extra["k"])
+	traceContext := background.Contexts["trace"].(*sentry.TraceContext)
+	require.Equal(t, transactionSpan.TraceID, traceContext.TraceID)
+	require.Equal(t, transactionSpan.SpanID, traceContext.ParentSpanID)
+	require.Len(t, background.Spans, 1)
+	require.Equal(t, traceContext.SpanID, background.Spans[0].ParentSpanID)
+}
+
+func (s *ForkSuite) TestForkDetachNoop(_ context.Context, t *testing.T) {
+	ctx := logz.Fork(context.Background())
+	logz.Get(ctx).Debug("message")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx = logz.Detach(ctx)
+	require.NoError(t, ctx.Err())
+	logz.Get(ctx).Debug("message")
+}
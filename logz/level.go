@@ -1,16 +1,97 @@
 package logz
 
 import (
+	"encoding"
+	"flag"
+	"strings"
+
 	"github.com/getsentry/sentry-go"
 	"github.com/ibrt/golang-errors/errorz"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	_ Option                   = levelOption(Debug)
+	_ encoding.TextMarshaler   = Debug
+	_ encoding.TextUnmarshaler = (*Level)(nil)
+	_ flag.Value               = (*Level)(nil)
+)
+
 // Level describes a level.
 type Level string
 
+// ParseLevel parses a level case-insensitively, also accepting the "warn" and "err" aliases.
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(strings.ToLower(strings.TrimSpace(s))); l {
+	case Trace, Debug, Info, Warning, Error, Fatal:
+		return l, nil
+	case "warn":
+		return Warning, nil
+	case "err":
+		return Error, nil
+	default:
+		return "", errorz.Errorf("unknown level: %v", errorz.A(s), errorz.Skip())
+	}
+}
+
+// Enabled returns true if the level is at or above the given minimum level, false if either level is unknown or unset.
+func (l Level) Enabled(min Level) bool {
+	severity, ok := l.severity()
+	minSeverity, minOK := min.severity()
+	return ok && minOK && severity >= minSeverity
+}
+
+// String implements the fmt.Stringer and flag.Value interfaces.
+func (l Level) String() string {
+	return string(l)
+}
+
+// Set implements the flag.Value interface.
+func (l *Level) Set(s string) error {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return errorz.Wrap(err, errorz.Skip())
+	}
+
+	*l = level
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, an unset level is marshaled as empty text.
+func (l Level) MarshalText() ([]byte, error) {
+	if l == "" {
+		return []byte{}, nil
+	}
+
+	if _, ok := l.severity(); !ok {
+		return nil, errorz.Errorf("unknown level: %v", errorz.A(l), errorz.Skip())
+	}
+
+	return []byte(l), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, empty text leaves the level unset.
+func (l *Level) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = ""
+		return nil
+	}
+
+	return l.Set(string(text))
+}
+
+// levelOption overrides the level of an entry, it is a distinct type so that it can be found before creating the entry.
+type levelOption Level
+
+// Apply implements the Option interface.
+func (o levelOption) Apply(e *entry) {
+	e.level = Level(o)
+}
+
 func (l Level) toLogrus() logrus.Level {
 	switch l {
+	case Trace:
+		return logrus.TraceLevel
 	case Debug:
 		return logrus.DebugLevel
 	case Info:
@@ -19,6 +100,8 @@ func (l Level) toLogrus() logrus.Level {
 		return logrus.WarnLevel
 	case Error:
 		return logrus.ErrorLevel
+	case Fatal:
+		return logrus.FatalLevel
 	default:
 		panic(errorz.Errorf("unknown level: %v", errorz.A(l), errorz.SkipPackage()))
 	}
@@ -26,6 +109,8 @@ func (l Level) toLogrus() logrus.Level {
 
 func (l Level) toSentry() sentry.Level {
 	switch l {
+	case Trace:
+		return sentryLevelTrace
 	case Debug:
 		return sentry.LevelDebug
 	case Info:
@@ -34,14 +119,37 @@ func (l Level) toSentry() sentry.Level {
 		return sentry.LevelWarning
 	case Error:
 		return sentry.LevelError
+	case Fatal:
+		return sentry.LevelFatal
 	default:
 		panic(errorz.Errorf("unknown level: %v", errorz.A(l), errorz.SkipPackage()))
 	}
 }
 
+func (l Level) severity() (int, bool) {
+	switch l {
+	case Trace:
+		return -1, true
+	case Debug:
+		return 0, true
+	case Info:
+		return 1, true
+	case Warning:
+		return 2, true
+	case Error:
+		return 3, true
+	case Fatal:
+		return 4, true
+	default:
+		return 0, false
+	}
+}
+
 func levelFromSentry(l sentry.Level) Level {
 	switch l {
-	case sentry.LevelFatal, sentry.LevelError:
+	case sentry.LevelFatal:
+		return Fatal
+	case sentry.LevelError:
 		return Error
 	case sentry.LevelWarning:
 		return Warning
@@ -49,15 +157,22 @@ func levelFromSentry(l sentry.Level) Level {
 		return Info
 	case sentry.LevelDebug:
 		return Debug
+	case sentryLevelTrace:
+		return Trace
 	default:
 		panic(errorz.Errorf("unknown level: %v", errorz.A(l), errorz.SkipPackage()))
 	}
 }
 
-// Known levels.
+// Known levels, Trace entries are never sent to Sentry.
 const (
+	Trace   Level = "trace"
 	Debug   Level = "debug"
 	Info    Level = "info"
 	Warning Level = "warning"
 	Error   Level = "error"
+	Fatal   Level = "fatal"
 )
+
+// sentryLevelTrace is used for Trace events, which are output but never sent to Sentry.
+const sentryLevelTrace sentry.Level = "trace"
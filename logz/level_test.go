@@ -1,6 +1,9 @@
 package logz
 
 import (
+	"encoding/json"
+	"flag"
+	"io"
 	"testing"
 
 	"github.com/getsentry/sentry-go"
@@ -10,19 +13,32 @@ import (
 )
 
 func TestLevel(t *testing.T) {
+	require.Equal(t, sentryLevelTrace, Trace.toSentry())
 	require.Equal(t, sentry.LevelDebug, Debug.toSentry())
 	require.Equal(t, sentry.LevelInfo, Info.toSentry())
 	require.Equal(t, sentry.LevelWarning, Warning.toSentry())
 	require.Equal(t, sentry.LevelError, Error.toSentry())
+	require.Equal(t, sentry.LevelFatal, Fatal.toSentry())
+	require.Equal(t, logrus.TraceLevel, Trace.toLogrus())
 	require.Equal(t, logrus.DebugLevel, Debug.toLogrus())
 	require.Equal(t, logrus.InfoLevel, Info.toLogrus())
 	require.Equal(t, logrus.WarnLevel, Warning.toLogrus())
 	require.Equal(t, logrus.ErrorLevel, Error.toLogrus())
+	require.Equal(t, logrus.FatalLevel, Fatal.toLogrus())
+	requireSeverity(t, -1, Trace)
+	requireSeverity(t, 0, Debug)
+	requireSeverity(t, 1, Info)
+	requireSeverity(t, 2, Warning)
+	requireSeverity(t, 3, Error)
+	requireSeverity(t, 4, Fatal)
+	_, ok := Level("unknown").severity()
+	require.False(t, ok)
+	require.Equal(t, Trace, levelFromSentry(sentryLevelTrace))
 	require.Equal(t, Debug, levelFromSentry(sentry.LevelDebug))
 	require.Equal(t, Info, levelFromSentry(sentry.LevelInfo))
 	require.Equal(t, Warning, levelFromSentry(sentry.LevelWarning))
 	require.Equal(t, Error, levelFromSentry(sentry.LevelError))
-	require.Equal(t, Error, levelFromSentry(sentry.LevelFatal))
+	require.Equal(t, Fatal, levelFromSentry(sentry.LevelFatal))
 
 	fixturez.RequirePanicsWith(t, "unknown level: unknown", func() {
 		Level("unknown").toSentry()
@@ -36,3 +52,95 @@ func TestLevel(t *testing.T) {
 		levelFromSentry("unknown")
 	})
 }
+
+func requireSeverity(t *testing.T, expected int, l Level) {
+	severity, ok := l.severity()
+	require.True(t, ok)
+	require.Equal(t, expected, severity)
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, level := range map[string]Level{
+		"trace":   Trace,
+		"DEBUG":   Debug,
+		" Info ":  Info,
+		"warning": Warning,
+		"warn":    Warning,
+		"WARN":    Warning,
+		"error":   Error,
+		"err":     Error,
+		"Fatal":   Fatal,
+	} {
+		l, err := ParseLevel(s)
+		fixturez.RequireNoError(t, err)
+		require.Equal(t, level, l)
+	}
+
+	_, err := ParseLevel("unknown")
+	require.EqualError(t, err, "unknown level: unknown")
+	_, err = ParseLevel("")
+	require.Error(t, err)
+}
+
+func TestLevelEnabled(t *testing.T) {
+	require.True(t, Error.Enabled(Error))
+	require.True(t, Fatal.Enabled(Trace))
+	require.True(t, Debug.Enabled(Trace))
+	require.False(t, Trace.Enabled(Debug))
+	require.False(t, Warning.Enabled(Error))
+
+	fixturez.RequireNotPanics(t, func() {
+		require.False(t, Level("unknown").Enabled(Debug))
+		require.False(t, Level("").Enabled(Trace))
+		require.False(t, Fatal.Enabled(""))
+	})
+}
+
+func TestLevelMarshaling(t *testing.T) {
+	buf, err := json.Marshal(map[string]Level{"level": Warning})
+	fixturez.RequireNoError(t, err)
+	require.Equal(t, `{"level":"warning"}`, string(buf))
+
+	_, err = json.Marshal(Level("unknown"))
+	require.Error(t, err)
+	_, err = json.Marshal(Level("WARN"))
+	require.Error(t, err)
+
+	buf, err = json.Marshal(map[string]Level{"level": ""})
+	fixturez.RequireNoError(t, err)
+	require.Equal(t, `{"level":""}`, string(buf))
+	unset := map[string]Level{}
+	fixturez.RequireNoError(t, json.Unmarshal(buf, &unset))
+	require.Equal(t, map[string]Level{"level": ""}, unset)
+
+	asyncCfg := &AsyncConfig{QueueSize: 1, Workers: 1, FullQueuePolicy: BlockPolicy}
+	buf, err = json.Marshal(asyncCfg)
+	fixturez.RequireNoError(t, err)
+	require.Equal(t, `{"queueSize":1,"workers":1,"fullQueuePolicy":"block","dropBelowLevel":""}`, string(buf))
+	unmarshaledAsyncCfg := &AsyncConfig{}
+	fixturez.RequireNoError(t, json.Unmarshal(buf, unmarshaledAsyncCfg))
+	require.Equal(t, asyncCfg, unmarshaledAsyncCfg)
+
+	levels := &Levels{}
+	fixturez.RequireNoError(t, json.Unmarshal([]byte(`{"outputLevel":"WARN","sentryLevel":"err","outputLevelOverrides":{"c":"Trace"}}`), levels))
+	require.Equal(t, &Levels{OutputLevel: Warning, SentryLevel: Error, OutputLevelOverrides: map[string]Level{"c": Trace}}, levels)
+
+	level := Debug
+	fixturez.RequireNoError(t, level.UnmarshalText(nil))
+	require.Equal(t, Level(""), level)
+	require.Error(t, json.Unmarshal([]byte(`{"outputLevel":"unknown"}`), levels))
+}
+
+func TestLevelFlag(t *testing.T) {
+	level := Info
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&level, "level", "level")
+	require.Equal(t, "info", fs.Lookup("level").DefValue)
+
+	fixturez.RequireNoError(t, fs.Parse([]string{"-level", "Warn"}))
+	require.Equal(t, Warning, level)
+	require.Equal(t, "warning", level.String())
+	require.Error(t, fs.Parse([]string{"-level", "unknown"}))
+	require.Equal(t, Warning, level)
+}
@@ -0,0 +1,172 @@
+package logz
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-inject-clock/clockz"
+	"github.com/ibrt/golang-validation/vz"
+)
+
+const (
+	loggerTagKey        = "logger"
+	loggerNameSeparator = "."
+)
+
+// Levels describes the effective output and Sentry levels, including per-component overrides.
+type Levels struct {
+	OutputLevel          Level            `json:"outputLevel" validate:"required,oneof=trace debug info warning error fatal"`
+	SentryLevel          Level            `json:"sentryLevel" validate:"required,oneof=debug info warning error fatal"`
+	OutputLevelOverrides map[string]Level `json:"outputLevelOverrides,omitempty" validate:"dive,keys,required,endkeys,oneof=trace debug info warning error fatal"`
+	SentryLevelOverrides map[string]Level `json:"sentryLevelOverrides,omitempty" validate:"dive,keys,required,endkeys,oneof=debug info warning error fatal"`
+}
+
+func newLevels(cfg *Config) *Levels {
+	return (&Levels{
+		OutputLevel:          cfg.OutputLevel,
+		SentryLevel:          cfg.SentryLevel,
+		OutputLevelOverrides: cfg.OutputLevelOverrides,
+		SentryLevelOverrides: cfg.SentryLevelOverrides,
+	}).clone()
+}
+
+// Validate implements the vz.Validator interface.
+func (l *Levels) Validate() error {
+	return errorz.MaybeWrap(vz.ValidateStruct(l), errorz.Skip())
+}
+
+// getOutputLevel returns the output level for the given logger name.
+func (l *Levels) getOutputLevel(logger string) Level {
+	return resolveLevel(l.OutputLevel, l.OutputLevelOverrides, logger)
+}
+
+// getSentryLevel returns the Sentry level for the given logger name.
+func (l *Levels) getSentryLevel(logger string) Level {
+	return resolveLevel(l.SentryLevel, l.SentryLevelOverrides, logger)
+}
+
+func (l *Levels) clone() *Levels {
+	return &Levels{
+		OutputLevel:          l.OutputLevel,
+		SentryLevel:          l.SentryLevel,
+		OutputLevelOverrides: cloneLevelOverrides(l.OutputLevelOverrides),
+		SentryLevelOverrides: cloneLevelOverrides(l.SentryLevelOverrides),
+	}
+}
+
+func cloneLevelOverrides(overrides map[string]Level) map[string]Level {
+	if overrides == nil {
+		return nil
+	}
+
+	clone := make(map[string]Level, len(overrides))
+	for k, v := range overrides {
+		clone[k] = v
+	}
+	return clone
+}
+
+// levelsController holds the effective Levels, which can be changed at runtime and reverted after a TTL.
+type levelsController struct {
+	defaultLevels *Levels
+	levels        atomic.Value
+	m             sync.Mutex
+	stopRevert    func() bool
+}
+
+func newLevelsController(defaultLevels *Levels) *levelsController {
+	c := &levelsController{
+		defaultLevels: defaultLevels,
+	}
+	c.levels.Store(defaultLevels)
+	return c
+}
+
+// get returns the effective Levels, which must not be modified.
+func (c *levelsController) get() *Levels {
+	return c.levels.Load().(*Levels)
+}
+
+// set atomically replaces the effective Levels, reverting to the default ones after ttl if greater than zero.
+func (c *levelsController) set(ctx context.Context, levels *Levels, ttl time.Duration) error {
+	if err := levels.Validate(); err != nil {
+		return errorz.Wrap(err, errorz.SkipPackage())
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.cancelRevertLocked()
+	c.levels.Store(levels.clone())
+
+	if ttl > 0 {
+		var timerFired bool
+		stop := clockz.Get(ctx).AfterFunc(ttl, func() {
+			c.m.Lock()
+			defer c.m.Unlock()
+
+			if !timerFired {
+				timerFired = true
+				c.stopRevert = nil
+				c.levels.Store(c.defaultLevels)
+			}
+		}).Stop
+
+		c.stopRevert = func() bool {
+			timerFired = true
+			return stop()
+		}
+	}
+
+	return nil
+}
+
+// reset atomically restores the default Levels.
+func (c *levelsController) reset() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.cancelRevertLocked()
+	c.levels.Store(c.defaultLevels)
+}
+
+func (c *levelsController) cancelRevertLocked() {
+	if c.stopRevert != nil {
+		c.stopRevert()
+		c.stopRevert = nil
+	}
+}
+
+// resolveLevel returns the override with the longest prefix matching the logger name, or the default level.
+// Prefixes only match whole name components, i.e. "billing" matches "billing.invoices" but not "billingx".
+func resolveLevel(defaultLevel Level, overrides map[string]Level, logger string) Level {
+	level := defaultLevel
+	matchLen := -1
+
+	for prefix, override := range overrides {
+		if len(prefix) > matchLen && isLoggerPrefix(prefix, logger) {
+			level = override
+			matchLen = len(prefix)
+		}
+	}
+
+	return level
+}
+
+func isLoggerPrefix(prefix, logger string) bool {
+	return logger == prefix || strings.HasPrefix(logger, prefix+loggerNameSeparator)
+}
+
+func joinLoggerNames(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + loggerNameSeparator + name
+}
@@ -0,0 +1,65 @@
+package logz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLevel(t *testing.T) {
+	overrides := map[string]Level{
+		"billing":          Debug,
+		"billing.invoices": Warning,
+		"billing.in":       Error,
+	}
+
+	require.Equal(t, Info, resolveLevel(Info, nil, "billing"))
+	require.Equal(t, Info, resolveLevel(Info, overrides, ""))
+	require.Equal(t, Info, resolveLevel(Info, overrides, "other"))
+	require.Equal(t, Info, resolveLevel(Info, overrides, "billingx"))
+	require.Equal(t, Debug, resolveLevel(Info, overrides, "billing"))
+	require.Equal(t, Debug, resolveLevel(Info, overrides, "billing.payments"))
+	require.Equal(t, Warning, resolveLevel(Info, overrides, "billing.invoices"))
+	require.Equal(t, Warning, resolveLevel(Info, overrides, "billing.invoices.pdf"))
+	require.Equal(t, Error, resolveLevel(Info, overrides, "billing.in"))
+
+	l := newLevels(&Config{
+		OutputLevel:          Info,
+		SentryLevel:          Error,
+		OutputLevelOverrides: map[string]Level{"billing": Debug},
+		SentryLevelOverrides: map[string]Level{"billing": Warning},
+	})
+	require.Equal(t, Info, l.getOutputLevel(""))
+	require.Equal(t, Debug, l.getOutputLevel("billing.invoices"))
+	require.Equal(t, Error, l.getSentryLevel(""))
+	require.Equal(t, Warning, l.getSentryLevel("billing.invoices"))
+}
+
+func TestJoinLoggerNames(t *testing.T) {
+	require.Equal(t, "", joinLoggerNames("", ""))
+	require.Equal(t, "a", joinLoggerNames("", "a"))
+	require.Equal(t, "a", joinLoggerNames("a", ""))
+	require.Equal(t, "a.b", joinLoggerNames("a", "b"))
+}
+
+func TestConfigLevelOverrides(t *testing.T) {
+	cfg := &Config{
+		SentryLevel:            Debug,
+		OutputLevel:            Debug,
+		OutputFormat:           JSON,
+		SentrySampleRate:       1,
+		SentryTracesSampleRate: 1,
+		OutputLevelOverrides:   map[string]Level{"billing": Debug},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.OutputLevelOverrides["billing"] = "unknown"
+	require.Error(t, cfg.Validate())
+
+	cfg.OutputLevelOverrides = map[string]Level{"": Debug}
+	require.Error(t, cfg.Validate())
+
+	cfg.OutputLevelOverrides = nil
+	cfg.SentryLevelOverrides = map[string]Level{"billing": "unknown"}
+	require.Error(t, cfg.Validate())
+}
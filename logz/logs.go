@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -21,6 +22,12 @@ const (
 	logsConfigContextKey contextKey = iota
 	logsContextKey
 	logsSpanContextKey
+	logsPropagationContextKey
+	logsBindingContextKey
+)
+
+const (
+	minFatalFlushTimeout = 2 * time.Second
 )
 
 var (
@@ -29,6 +36,7 @@ var (
 	_ ContextLogs = &contextLogsImpl{}
 
 	noopLogs = &noopLogsImpl{}
+	osExit   = os.Exit
 )
 
 // OutputFormat describes the format for output logs.
@@ -43,10 +51,13 @@ const (
 // BeforeSendFunc describes a function called before sending out an event.
 type BeforeSendFunc func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event
 
+// FingerprintFunc describes a function which computes the fingerprint of an error, or returns nil to use the default.
+type FingerprintFunc func(err error) []string
+
 // Config describes the configuration for Logs.
 type Config struct {
-	SentryLevel            Level            `json:"sentryLevel" validate:"required,oneof=debug info warning error"`
-	OutputLevel            Level            `json:"outputLevel" validate:"required,oneof=debug info warning error"`
+	SentryLevel            Level            `json:"sentryLevel" validate:"required,oneof=debug info warning error fatal"`
+	OutputLevel            Level            `json:"outputLevel" validate:"required,oneof=trace debug info warning error fatal"`
 	OutputFormat           OutputFormat     `json:"format" validate:"required,oneof=text json"`
 	SentryDSN              string           `json:"sentryDsn"`
 	SentrySampleRate       float64          `json:"sentrySampleRate" validate:"required"`
@@ -56,11 +67,34 @@ type Config struct {
 	Environment            string           `json:"environment"`
 	Release                string           `json:"release"`
 	ServerName             string           `json:"serverName"`
+	Propagators            []Propagator     `json:"propagators" validate:"dive,oneof=sentry w3c baggage b3 b3multi"`
+	SentryLevelOverrides   map[string]Level `json:"sentryLevelOverrides" validate:"dive,keys,required,endkeys,oneof=debug info warning error fatal"`
+	OutputLevelOverrides   map[string]Level `json:"outputLevelOverrides" validate:"dive,keys,required,endkeys,oneof=trace debug info warning error fatal"`
+	Fingerprinter          FingerprintFunc  `json:"-"`
+	Breadcrumbs            bool             `json:"breadcrumbs"`
+	MaxBreadcrumbs         int              `json:"maxBreadcrumbs" validate:"min=0,max=100"`
+	Redaction              *RedactionConfig `json:"redaction"`
+	BeforeSend             BeforeSendFunc   `json:"-"`
+	BeforeSendTransaction  BeforeSendFunc   `json:"-"`
+	OutputDroppedEvents    bool             `json:"outputDroppedEvents"`
+	StackCapture           StackCapture     `json:"stackCapture" validate:"omitempty,oneof=off sentry always"`
+	MaxStackDepth          int              `json:"maxStackDepth" validate:"min=0,max=1024"`
+	ExitFunc               func(code int)   `json:"-"`
+	TraceFields            *TraceFields     `json:"traceFields"`
+	Async                  *AsyncConfig     `json:"async"`
 }
 
 // Validate implements the vz.Validator interface.
 func (c *Config) Validate() error {
-	return errorz.MaybeWrap(vz.ValidateStruct(c), errorz.Skip())
+	if err := vz.ValidateStruct(c); err != nil {
+		return errorz.Wrap(err, errorz.Skip())
+	}
+
+	if c.Redaction != nil {
+		return errorz.MaybeWrap(c.Redaction.Validate(), errorz.Skip())
+	}
+
+	return nil
 }
 
 // NewConfigSingletonInjector always inject the given *Config.
@@ -72,44 +106,152 @@ func NewConfigSingletonInjector(cfg *Config) injectz.Injector {
 
 // Logs describes the logs module.
 type Logs interface {
+	Trace(ctx context.Context, skipCallers int, format string, options ...Option)
 	Debug(ctx context.Context, skipCallers int, format string, options ...Option)
 	Info(ctx context.Context, skipCallers int, format string, options ...Option)
 	Warning(ctx context.Context, err error)
 	Error(ctx context.Context, err error)
+	Fatal(ctx context.Context, err error)
 	TraceHTTPRequestServer(ctx context.Context, req *http.Request, reqBody []byte) (context.Context, func())
 	TraceHTTPRequestServerSimple(ctx context.Context, req *sentry.Request) (context.Context, func())
 	TraceSpan(ctx context.Context, op, desc string) (context.Context, func())
+	StartSpan(ctx context.Context, op, desc string) (context.Context, Span)
+	TraceTransaction(ctx context.Context, op, name string, options ...TransactionOption) (context.Context, func(err error))
 	SetUser(ctx context.Context, user *User)
 	AddMetadata(ctx context.Context, k string, v interface{})
+	AddTag(ctx context.Context, k, v string)
+	With(ctx context.Context, options ...Option) context.Context
+	Named(ctx context.Context, name string) context.Context
+	Enabled(ctx context.Context, level Level) bool
+	GetLevels(ctx context.Context) *Levels
+	SetLevels(ctx context.Context, levels *Levels, ttl time.Duration) error
+	ResetLevels(ctx context.Context)
+	Flush(ctx context.Context) bool
+	GetAsyncStats(ctx context.Context) *AsyncStats
+}
+
+// Span describes a span started by StartSpan, its methods are not safe for concurrent use.
+type Span interface {
+	SetStatus(status sentry.SpanStatus)
+	SetTag(k, v string)
+	SetData(k string, v interface{})
+	RecordError(err error)
+	Finish()
+	FinishWithError(err error)
 }
 
 type logsImpl struct {
-	logrusLogger *logrus.Logger
-	sentryHub    *sentry.Hub
+	logrusLogger  *logrus.Logger
+	sentryHub     *sentry.Hub
+	propagators   []Propagator
+	levels        *levelsController
+	fingerprinter FingerprintFunc
+	breadcrumbs   bool
+	flushTimeout  time.Duration
+	async         *asyncQueue
+	stackCapture  StackCapture
+	maxStackDepth int
+	exit          func(code int)
+}
+
+// Trace logs a trace message, which is never sent to Sentry.
+func (l *logsImpl) Trace(ctx context.Context, skipCallers int, format string, options ...Option) {
+	l.captureEntry(ctx, Trace, skipCallers+1, format, options...)
 }
 
 // Debug logs a debug message.
 func (l *logsImpl) Debug(ctx context.Context, skipCallers int, format string, options ...Option) {
-	sentry.GetHubFromContext(ctx).CaptureEvent(
-		newEntry(ctx, Debug, skipCallers+1, format, options...).toSentryEvent())
+	l.captureEntry(ctx, Debug, skipCallers+1, format, options...)
 }
 
 // Info logs an info message.
 func (l *logsImpl) Info(ctx context.Context, skipCallers int, format string, options ...Option) {
-	sentry.GetHubFromContext(ctx).CaptureEvent(
-		newEntry(ctx, Info, skipCallers+1, format, options...).toSentryEvent())
+	l.captureEntry(ctx, Info, skipCallers+1, format, options...)
+}
+
+// captureEntry creates and captures an entry, also recording it as a breadcrumb if below the Sentry level in
+// breadcrumbs mode. Levels are checked before creating the entry, so that filtered entries are cheap.
+func (l *logsImpl) captureEntry(ctx context.Context, level Level, skipCallers int, format string, options ...Option) {
+	level = getOptionsLevel(level, options)
+	isSentryBound, isOutput, isBreadcrumb := l.getEntrySinks(getBinding(ctx), level, options)
+
+	if !isSentryBound && !isOutput && !isBreadcrumb {
+		return
+	}
+
+	maxStackDepth := 0
+	if l.stackCapture.isEnabled(isSentryBound) {
+		maxStackDepth = l.maxStackDepth
+	}
+
+	e := newEntry(ctx, level, skipCallers+1, maxStackDepth, format, options...)
+	event := e.toSentryEvent()
+	e.release()
+	setEventSpan(ctx, event)
+
+	hub := sentry.GetHubFromContext(ctx)
+	hub.CaptureEvent(event)
+
+	if isBreadcrumb {
+		hub.AddBreadcrumb(e.toSentryBreadcrumb(), nil)
+	}
+}
+
+// getEntrySinks returns whether an entry at the given level would be sent to Sentry, output, or recorded as a
+// breadcrumb, according to the effective levels for the bound logger name. Trace entries never reach Sentry.
+func (l *logsImpl) getEntrySinks(b *binding, level Level, options []Option) (bool, bool, bool) {
+	levels := l.levels.get()
+	isSentryBound := level.Enabled(levels.getSentryLevel(b.getLogger()))
+	isOutput := level.Enabled(levels.getOutputLevel(b.getLogger()))
+	isBreadcrumb := level != Trace && !isSentryBound && getOptionsBreadcrumb(l.breadcrumbs, b, options)
+	return isSentryBound, isOutput, isBreadcrumb
+}
+
+// isEnabled returns true if an error at the given level would be output or sent to Sentry.
+func (l *logsImpl) isEnabled(ctx context.Context, level Level) bool {
+	isSentryBound, isOutput, _ := l.getEntrySinks(getBinding(ctx), level, nil)
+	return isSentryBound || isOutput
+}
+
+// Enabled returns true if an entry at the given level would be emitted, i.e. output, sent to Sentry or recorded as a
+// breadcrumb. It can be used to skip building expensive entries, see also Lazy and LazyA.
+func (l *logsImpl) Enabled(ctx context.Context, level Level) bool {
+	isSentryBound, isOutput, isBreadcrumb := l.getEntrySinks(getBinding(ctx), level, nil)
+	return isSentryBound || isOutput || isBreadcrumb
 }
 
 // Warning logs a warning.
 func (l *logsImpl) Warning(ctx context.Context, err error) {
-	sentry.GetHubFromContext(ctx).CaptureEvent(
-		errorToSentryEvent(ctx, errorz.Wrap(err, errorz.SkipPackage()), Warning))
+	if l.isEnabled(ctx, Warning) {
+		l.captureError(ctx, errorz.Wrap(err, errorz.SkipPackage()), Warning)
+	}
 }
 
 // Error logs an error.
 func (l *logsImpl) Error(ctx context.Context, err error) {
-	sentry.GetHubFromContext(ctx).CaptureEvent(
-		errorToSentryEvent(ctx, errorz.Wrap(err, errorz.SkipPackage()), Error))
+	if l.isEnabled(ctx, Error) {
+		l.captureError(ctx, errorz.Wrap(err, errorz.SkipPackage()), Error)
+	}
+}
+
+// Fatal logs a fatal error, then flushes and exits with status 1.
+func (l *logsImpl) Fatal(ctx context.Context, err error) {
+	l.captureError(ctx, errorz.Wrap(err, errorz.SkipPackage()), Fatal)
+	l.sentryHub.Flush(max(l.flushTimeout, minFatalFlushTimeout)) // flushTimeout may be zero, which drops async events
+	l.exit(1)
+}
+
+// captureError captures the error, using the configured fingerprinter unless the error has an explicit fingerprint.
+func (l *logsImpl) captureError(ctx context.Context, err error, level Level) {
+	event := errorToSentryEvent(ctx, err, level)
+
+	if len(event.Fingerprint) == 0 && l.fingerprinter != nil {
+		event.Fingerprint = l.fingerprinter(err)
+	}
+
+	setEventSpan(ctx, event)
+
+	sentry.GetHubFromContext(ctx).CaptureEvent(event)
 }
 
 // TraceHTTPRequestServer starts tracing an inbound HTTP request.
@@ -117,12 +259,13 @@ func (l *logsImpl) TraceHTTPRequestServer(ctx context.Context, req *http.Request
 	sentryHub := sentry.GetHubFromContext(ctx).Clone()
 	ctx = sentry.SetHubOnContext(ctx, sentryHub)
 
+	tc := extractTraceContext(l.propagators, headerCarrier(req.Header))
 	span := sentry.StartSpan(ctx, "http.server",
 		sentry.TransactionName(fmt.Sprintf("%s %s", req.Method, req.URL.Path)),
-		sentry.ContinueFromRequest(req))
+		newTraceSpanOption(tc))
 
 	span.StartTime = clockz.Get(ctx).Now()
-	ctx = span.Context()
+	ctx = tc.toContext(span.Context())
 
 	sentryHub.Scope().SetRequest(req)
 	if reqBody != nil {
@@ -147,12 +290,13 @@ func (l *logsImpl) TraceHTTPRequestServerSimple(ctx context.Context, req *sentry
 		}
 	}
 
+	tc := extractTraceContext(l.propagators, mapCarrier(req.Headers))
 	span := sentry.StartSpan(ctx, "http.server",
 		sentry.TransactionName(transactionName),
-		newTraceSpanOption(req.Headers))
+		newTraceSpanOption(tc))
 
 	span.StartTime = clockz.Get(ctx).Now()
-	ctx = span.Context()
+	ctx = tc.toContext(span.Context())
 
 	sentryHub.Scope().SetExtra(logsRequestExtraKey, req)
 
@@ -164,7 +308,18 @@ func (l *logsImpl) TraceHTTPRequestServerSimple(ctx context.Context, req *sentry
 
 // TraceSpan starts tracing a span, for example an outgoing HTTP request or database query.
 func (l *logsImpl) TraceSpan(ctx context.Context, op, desc string) (context.Context, func()) {
-	span := sentry.StartSpan(ctx, op)
+	ctx, span := l.StartSpan(ctx, op, desc)
+	return ctx, span.Finish
+}
+
+// StartSpan starts tracing a span like TraceSpan, returning a Span which allows setting its status, tags and data.
+func (l *logsImpl) StartSpan(ctx context.Context, op, desc string) (context.Context, Span) {
+	var options []sentry.SpanOption
+	if sentry.TransactionFromContext(ctx) == nil {
+		options = append(options, newTraceSpanOption(getPropagatedTraceContext(ctx))) // continues a detached or extracted trace
+	}
+
+	span := sentry.StartSpan(ctx, op, options...)
 	span.StartTime = clockz.Get(ctx).Now()
 	span.Data = make(map[string]interface{})
 	span.Description = desc
@@ -172,7 +327,43 @@ func (l *logsImpl) TraceSpan(ctx context.Context, op, desc string) (context.Cont
 	ctx = span.Context()
 	ctx = context.WithValue(ctx, logsSpanContextKey, span)
 
-	return ctx, func() {
+	return ctx, &spanImpl{
+		ctx:  ctx,
+		logs: l,
+		span: span,
+	}
+}
+
+// TraceTransaction starts tracing a root transaction with a cloned hub, for example a background job or queue message.
+// The returned function finishes the transaction, setting its status from the given error and reporting it if not nil.
+func (l *logsImpl) TraceTransaction(ctx context.Context, op, name string, options ...TransactionOption) (context.Context, func(err error)) {
+	cfg := &transactionConfig{}
+	for _, o := range options {
+		o(cfg)
+	}
+
+	sentryHub := sentry.GetHubFromContext(ctx).Clone()
+	ctx = sentry.SetHubOnContext(&rootContext{Context: ctx}, sentryHub)
+
+	tc := extractTraceContext(l.propagators, mapCarrier(cfg.carrier))
+	if !tc.isValid {
+		tc = getPropagatedTraceContext(ctx) // continues a detached or extracted trace
+	}
+
+	span := sentry.StartSpan(ctx, op,
+		sentry.TransactionName(name),
+		newTraceSpanOption(tc))
+
+	span.StartTime = clockz.Get(ctx).Now()
+	ctx = tc.toContext(span.Context())
+
+	return ctx, func(err error) {
+		span.Status = errorToTransactionSpanStatus(err)
+
+		if err != nil {
+			l.captureError(ctx, errorz.Wrap(err, errorz.SkipPackage()), Error)
+		}
+
 		span.EndTime = clockz.Get(ctx).Now()
 		span.Finish()
 	}
@@ -200,9 +391,61 @@ func (l *logsImpl) AddMetadata(ctx context.Context, k string, v interface{}) {
 	sentry.GetHubFromContext(ctx).Scope().SetExtra(k, v)
 }
 
+// AddTag adds the given tag to the current scope, see T for limits.
+func (l *logsImpl) AddTag(ctx context.Context, k, v string) {
+	k, v = checkTag(k, v)
+
+	if span, ok := ctx.Value(logsSpanContextKey).(*sentry.Span); ok {
+		span.SetTag(k, v)
+		return
+	}
+
+	sentry.GetHubFromContext(ctx).Scope().SetTag(k, v)
+}
+
+// With binds the given options to the returned context, they are applied to every entry and error logged with it.
+func (l *logsImpl) With(ctx context.Context, options ...Option) context.Context {
+	return withBinding(ctx, options...)
+}
+
+// Named binds the given logger name to the returned context, nested names are joined with ".".
+func (l *logsImpl) Named(ctx context.Context, name string) context.Context {
+	return withBindingName(ctx, name)
+}
+
+// GetLevels returns a copy of the effective levels.
+func (l *logsImpl) GetLevels(_ context.Context) *Levels {
+	return l.levels.get().clone()
+}
+
+// SetLevels atomically changes the effective levels, reverting to the configured ones after ttl if greater than zero.
+func (l *logsImpl) SetLevels(ctx context.Context, levels *Levels, ttl time.Duration) error {
+	return errorz.MaybeWrap(l.levels.set(ctx, levels, ttl), errorz.SkipPackage())
+}
+
+// ResetLevels atomically restores the configured levels.
+func (l *logsImpl) ResetLevels(_ context.Context) {
+	l.levels.reset()
+}
+
+// Flush waits until pending events are sent or ReleaseTimeoutSeconds elapses, returning false on timeout.
+func (l *logsImpl) Flush(_ context.Context) bool {
+	return l.sentryHub.Flush(l.flushTimeout)
+}
+
+// GetAsyncStats returns the state of the async queue, or nil if async mode is disabled.
+func (l *logsImpl) GetAsyncStats(_ context.Context) *AsyncStats {
+	return l.async.stats()
+}
+
 type noopLogsImpl struct {
 }
 
+// Trace logs a trace message, which is never sent to Sentry.
+func (l *noopLogsImpl) Trace(_ context.Context, _ int, _ string, _ ...Option) {
+	// nothing to do here
+}
+
 // Debug logs a debug message.
 func (l *noopLogsImpl) Debug(_ context.Context, _ int, _ string, _ ...Option) {
 	// nothing to do here
@@ -223,6 +466,12 @@ func (l *noopLogsImpl) Error(_ context.Context, _ error) {
 	// nothing to do here
 }
 
+// Fatal writes the error to stderr, then exits with status 1.
+func (l *noopLogsImpl) Fatal(_ context.Context, err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+	osExit(1)
+}
+
 // TraceHTTPRequestServer starts tracing an inbound HTTP request.
 func (l *noopLogsImpl) TraceHTTPRequestServer(ctx context.Context, _ *http.Request, _ []byte) (context.Context, func()) {
 	return ctx, func() {}
@@ -238,6 +487,17 @@ func (l *noopLogsImpl) TraceSpan(ctx context.Context, _, _ string) (context.Cont
 	return ctx, func() {}
 }
 
+// StartSpan starts tracing a span like TraceSpan, returning a Span which allows setting its status, tags and data.
+func (l *noopLogsImpl) StartSpan(ctx context.Context, _, _ string) (context.Context, Span) {
+	return ctx, noopSpan
+}
+
+// TraceTransaction starts tracing a root transaction with a cloned hub, for example a background job or queue message.
+// The returned function finishes the transaction, setting its status from the given error and reporting it if not nil.
+func (l *noopLogsImpl) TraceTransaction(ctx context.Context, _, _ string, _ ...TransactionOption) (context.Context, func(err error)) {
+	return ctx, func(_ error) {}
+}
+
 // SetUser sets the user in the current scope.
 func (l *noopLogsImpl) SetUser(_ context.Context, _ *User) {
 	// nothing to do here
@@ -248,17 +508,76 @@ func (l *noopLogsImpl) AddMetadata(_ context.Context, _ string, _ interface{}) {
 	// nothing to do here
 }
 
+// AddTag adds the given tag to the current scope, see T for limits.
+func (l *noopLogsImpl) AddTag(_ context.Context, _, _ string) {
+	// nothing to do here
+}
+
+// With binds the given options to the returned context, they are applied to every entry and error logged with it.
+func (l *noopLogsImpl) With(ctx context.Context, _ ...Option) context.Context {
+	return ctx
+}
+
+// Named binds the given logger name to the returned context, nested names are joined with ".".
+func (l *noopLogsImpl) Named(ctx context.Context, _ string) context.Context {
+	return ctx
+}
+
+// Enabled returns true if an entry at the given level would be emitted, i.e. output, sent to Sentry or recorded as a
+// breadcrumb. It can be used to skip building expensive entries, see also Lazy and LazyA.
+func (l *noopLogsImpl) Enabled(_ context.Context, _ Level) bool {
+	return false
+}
+
+// GetLevels returns a copy of the effective levels.
+func (l *noopLogsImpl) GetLevels(_ context.Context) *Levels {
+	return nil
+}
+
+// SetLevels atomically changes the effective levels, reverting to the configured ones after ttl if greater than zero.
+func (l *noopLogsImpl) SetLevels(_ context.Context, _ *Levels, _ time.Duration) error {
+	return nil
+}
+
+// ResetLevels atomically restores the configured levels.
+func (l *noopLogsImpl) ResetLevels(_ context.Context) {
+	// nothing to do here
+}
+
+// Flush waits until pending events are sent or ReleaseTimeoutSeconds elapses, returning false on timeout.
+func (l *noopLogsImpl) Flush(_ context.Context) bool {
+	return true
+}
+
+// GetAsyncStats returns the state of the async queue, or nil if async mode is disabled.
+func (l *noopLogsImpl) GetAsyncStats(_ context.Context) *AsyncStats {
+	return nil
+}
+
 // ContextLogs describes a Logs with a cached context.
 type ContextLogs interface {
+	Trace(format string, options ...Option)
 	Debug(format string, options ...Option)
 	Info(format string, options ...Option)
 	Warning(err error)
 	Error(err error)
+	Fatal(err error)
 	TraceHTTPRequestServer(req *http.Request, reqBody []byte) (context.Context, func())
 	TraceHTTPRequestServerSimple(req *sentry.Request) (context.Context, func())
 	TraceSpan(op, desc string) (context.Context, func())
+	StartSpan(op, desc string) (context.Context, Span)
+	TraceTransaction(op, name string, options ...TransactionOption) (context.Context, func(err error))
 	SetUser(user *User)
 	AddMetadata(k string, v interface{})
+	AddTag(k, v string)
+	With(options ...Option) ContextLogs
+	Named(name string) ContextLogs
+	Enabled(level Level) bool
+	GetLevels() *Levels
+	SetLevels(levels *Levels, ttl time.Duration) error
+	ResetLevels()
+	Flush() bool
+	GetAsyncStats() *AsyncStats
 }
 
 type contextLogsImpl struct {
@@ -266,6 +585,11 @@ type contextLogsImpl struct {
 	logs Logs
 }
 
+// Trace logs a trace message, which is never sent to Sentry.
+func (l *contextLogsImpl) Trace(format string, options ...Option) {
+	l.logs.Trace(l.ctx, 1, format, options...)
+}
+
 // Debug logs a debug message.
 func (l *contextLogsImpl) Debug(format string, options ...Option) {
 	l.logs.Debug(l.ctx, 1, format, options...)
@@ -286,6 +610,11 @@ func (l *contextLogsImpl) Error(err error) {
 	l.logs.Error(l.ctx, errorz.Wrap(err, errorz.SkipPackage()))
 }
 
+// Fatal logs a fatal error, then flushes and exits with status 1.
+func (l *contextLogsImpl) Fatal(err error) {
+	l.logs.Fatal(l.ctx, errorz.Wrap(err, errorz.SkipPackage()))
+}
+
 // TraceHTTPRequestServer starts tracing an inbound HTTP request.
 func (l *contextLogsImpl) TraceHTTPRequestServer(req *http.Request, reqBody []byte) (context.Context, func()) {
 	return l.logs.TraceHTTPRequestServer(l.ctx, req, reqBody)
@@ -301,6 +630,17 @@ func (l *contextLogsImpl) TraceSpan(op, desc string) (context.Context, func()) {
 	return l.logs.TraceSpan(l.ctx, op, desc)
 }
 
+// StartSpan starts tracing a span like TraceSpan, returning a Span which allows setting its status, tags and data.
+func (l *contextLogsImpl) StartSpan(op, desc string) (context.Context, Span) {
+	return l.logs.StartSpan(l.ctx, op, desc)
+}
+
+// TraceTransaction starts tracing a root transaction with a cloned hub, for example a background job or queue message.
+// The returned function finishes the transaction, setting its status from the given error and reporting it if not nil.
+func (l *contextLogsImpl) TraceTransaction(op, name string, options ...TransactionOption) (context.Context, func(err error)) {
+	return l.logs.TraceTransaction(l.ctx, op, name, options...)
+}
+
 // SetUser sets the user in the current scope.
 func (l *contextLogsImpl) SetUser(user *User) {
 	l.logs.SetUser(l.ctx, user)
@@ -311,13 +651,66 @@ func (l *contextLogsImpl) AddMetadata(k string, v interface{}) {
 	l.logs.AddMetadata(l.ctx, k, v)
 }
 
+// AddTag adds the given tag to the current scope, see T for limits.
+func (l *contextLogsImpl) AddTag(k, v string) {
+	l.logs.AddTag(l.ctx, k, v)
+}
+
+// With returns a derived ContextLogs which applies the given options to every entry and error, without modifying the scope.
+func (l *contextLogsImpl) With(options ...Option) ContextLogs {
+	return &contextLogsImpl{
+		ctx:  l.logs.With(l.ctx, options...),
+		logs: l.logs,
+	}
+}
+
+// Named returns a derived ContextLogs which stamps the given logger name on every entry and error.
+// Nested names are joined with ".", per-component level overrides are resolved from the full name.
+func (l *contextLogsImpl) Named(name string) ContextLogs {
+	return &contextLogsImpl{
+		ctx:  l.logs.Named(l.ctx, name),
+		logs: l.logs,
+	}
+}
+
+// Enabled returns true if an entry at the given level would be emitted, i.e. output, sent to Sentry or recorded as a
+// breadcrumb. It can be used to skip building expensive entries, see also Lazy and LazyA.
+func (l *contextLogsImpl) Enabled(level Level) bool {
+	return l.logs.Enabled(l.ctx, level)
+}
+
+// GetLevels returns a copy of the effective levels.
+func (l *contextLogsImpl) GetLevels() *Levels {
+	return l.logs.GetLevels(l.ctx)
+}
+
+// SetLevels atomically changes the effective levels, reverting to the configured ones after ttl if greater than zero.
+func (l *contextLogsImpl) SetLevels(levels *Levels, ttl time.Duration) error {
+	return l.logs.SetLevels(l.ctx, levels, ttl)
+}
+
+// ResetLevels atomically restores the configured levels.
+func (l *contextLogsImpl) ResetLevels() {
+	l.logs.ResetLevels(l.ctx)
+}
+
+// Flush waits until pending events are sent or ReleaseTimeoutSeconds elapses, returning false on timeout.
+func (l *contextLogsImpl) Flush() bool {
+	return l.logs.Flush(l.ctx)
+}
+
+// GetAsyncStats returns the state of the async queue, or nil if async mode is disabled.
+func (l *contextLogsImpl) GetAsyncStats() *AsyncStats {
+	return l.logs.GetAsyncStats(l.ctx)
+}
+
 // Initializer is a Logs initializer which provides a default implementation using Logrus and Sentry.
 func Initializer(ctx context.Context) (injectz.Injector, injectz.Releaser) {
 	cfg := ctx.Value(logsConfigContextKey).(*Config)
 	errorz.MaybeMustWrap(cfg.Validate(), errorz.SkipPackage())
 
 	logrusLogger := logrus.New()
-	logrusLogger.SetLevel(cfg.OutputLevel.toLogrus())
+	logrusLogger.SetLevel(Trace.toLogrus()) // levels are enforced by the transport
 
 	if cfg.OutputFormat == JSON {
 		logrusLogger.SetFormatter(&logrus.JSONFormatter{
@@ -329,6 +722,9 @@ func Initializer(ctx context.Context) (injectz.Injector, injectz.Releaser) {
 		})
 	}
 
+	levels := newLevelsController(newLevels(cfg))
+	transport := newLogsTransport(cfg, logrusLogger, levels)
+
 	client, err := sentry.NewClient(sentry.ClientOptions{
 		Dsn:              cfg.SentryDSN,
 		SampleRate:       cfg.SentrySampleRate,
@@ -336,21 +732,53 @@ func Initializer(ctx context.Context) (injectz.Injector, injectz.Releaser) {
 		ServerName:       cfg.ServerName,
 		Release:          cfg.Release,
 		Environment:      cfg.Environment,
-		Transport:        newLogsTransport(logrusLogger, cfg.SentryTransport),
+		MaxBreadcrumbs:   cfg.MaxBreadcrumbs,
+		Transport:        transport,
 	})
 	errorz.MaybeMustWrap(err, errorz.SkipPackage())
+	client.AddEventProcessor(transport.processEvent)
 	sentryHub := sentry.NewHub(client, sentry.NewScope())
 
+	propagators := cfg.Propagators
+	if len(propagators) == 0 {
+		propagators = defaultPropagators
+	}
+
+	stackCapture := cfg.StackCapture
+	if stackCapture == "" {
+		stackCapture = StackCaptureAlways
+	}
+
+	maxStackDepth := cfg.MaxStackDepth
+	if maxStackDepth == 0 {
+		maxStackDepth = defaultMaxStackDepth
+	}
+
+	exit := cfg.ExitFunc
+	if exit == nil {
+		exit = osExit
+	}
+
 	return injectz.NewInjectors(
 			NewSingletonInjector(&logsImpl{
-				logrusLogger: logrusLogger,
-				sentryHub:    sentryHub,
+				logrusLogger:  logrusLogger,
+				sentryHub:     sentryHub,
+				propagators:   propagators,
+				levels:        levels,
+				fingerprinter: cfg.Fingerprinter,
+				breadcrumbs:   cfg.Breadcrumbs,
+				flushTimeout:  time.Duration(cfg.ReleaseTimeoutSeconds) * time.Second,
+				async:         transport.async,
+				stackCapture:  stackCapture,
+				maxStackDepth: maxStackDepth,
+				exit:          exit,
 			}),
 			func(ctx context.Context) context.Context {
 				return sentry.SetHubOnContext(ctx, sentryHub)
 			}),
 		func() {
-			client.Flush(time.Duration(cfg.ReleaseTimeoutSeconds) * time.Second)
+			client.Flush(time.Duration(cfg.ReleaseTimeoutSeconds) * time.Second) // drains the async queue, if any
+			transport.close()
 		}
 }
 
@@ -0,0 +1,23 @@
+package logz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoopLogsFatal(t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+
+	var codes []int
+	defer func(exit func(code int)) { osExit = exit }(osExit)
+	osExit = func(code int) { codes = append(codes, code) }
+
+	Get(context.Background()).Fatal(errorz.Errorf("test error"))
+	require.Equal(t, []int{1}, codes)
+	require.Equal(t, "fatal: test error\n", c.GetErrString())
+}
@@ -3,7 +3,11 @@ package logz_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,7 +49,7 @@ func (t *testTransport) SendEvent(event *sentry.Event) {
 	t.isFlushed = false
 }
 
-func setupLogs(ctx context.Context) (context.Context, func(), *testTransport) {
+func setupLogs(ctx context.Context, configure ...func(cfg *logz.Config)) (context.Context, func(), *testTransport) {
 	transport := &testTransport{}
 
 	cfg := &logz.Config{
@@ -62,6 +66,10 @@ func setupLogs(ctx context.Context) (context.Context, func(), *testTransport) {
 		SentryTransport:        transport,
 	}
 
+	for _, f := range configure {
+		f(cfg)
+	}
+
 	ctx = logz.NewConfigSingletonInjector(cfg)(ctx)
 	injector, releaser := logz.Initializer(ctx)
 	ctx = injector(ctx)
@@ -110,6 +118,7 @@ func (s *ModuleSuite) TestDebug(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Threads, 1)
 	require.NotNil(t, event.Threads[0].Stacktrace)
@@ -164,6 +173,7 @@ func (s *ModuleSuite) TestInfo(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Threads, 1)
 	require.NotNil(t, event.Threads[0].Stacktrace)
@@ -218,6 +228,7 @@ func (s *ModuleSuite) TestWarning(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Exception, 1)
 	require.NotNil(t, event.Exception[0].Stacktrace)
@@ -277,6 +288,7 @@ func (s *ModuleSuite) TestError(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Exception, 1)
 	require.NotNil(t, event.Exception[0].Stacktrace)
@@ -304,6 +316,329 @@ func (s *ModuleSuite) TestError(ctx context.Context, t *testing.T) {
 	}, event)
 }
 
+func (s *ModuleSuite) TestSentryLevel(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.SentryLevel = logz.Error
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("debug message")
+	require.Empty(t, transport.events)
+
+	logz.Get(ctx).Warning(errorz.Errorf("warning message"))
+	require.Empty(t, transport.events)
+
+	logz.Get(ctx).Error(errorz.Errorf("error message"))
+	require.Len(t, transport.events, 1)
+	require.Equal(t, sentry.LevelError, transport.events[0].Level)
+
+	out := c.GetErrString()
+	require.Contains(t, out, "debug message")
+	require.Contains(t, out, "warning message")
+	require.Contains(t, out, "error message")
+}
+
+func (s *ModuleSuite) TestOutputLevel(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Error
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("debug message")
+	require.Empty(t, c.GetErr())
+	require.Len(t, transport.events, 1)
+	require.Equal(t, sentry.LevelDebug, transport.events[0].Level)
+}
+
+func (s *ModuleSuite) TestWith(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	logs := logz.Get(ctx).With(logz.M("bk1", "bv1"), logz.M("bk2", "bv2"))
+	logs.With(logz.M("bk2", "override")).Debug("message", logz.M("bk1", "override"))
+	logs.Error(errorz.Errorf("message", errorz.M("bk1", "override")))
+	logz.Get(ctx).Info("message")
+
+	require.Len(t, transport.events, 3)
+	require.Equal(t, map[string]interface{}{"bk1": "override", "bk2": "override"}, transport.events[0].Extra)
+	require.Equal(t, map[string]interface{}{"bk1": "override", "bk2": "bv2"}, transport.events[1].Extra)
+	require.Empty(t, transport.events[2].Extra)
+	require.Contains(t, c.GetErrString(), `"bk2":"override"`)
+}
+
+func (s *ModuleSuite) TestFingerprint(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.Fingerprinter = func(err error) []string {
+			if id := errorz.GetID(err); id != "" {
+				return []string{id.String(), strconv.Itoa(errorz.GetStatus(err).Int())}
+			}
+			return nil
+		}
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("message", logz.Fingerprint("entry"))
+	logz.Get(ctx).Error(errorz.Errorf("explicit", errorz.ID("id"), logz.ErrorFingerprint("explicit", "{{ default }}")))
+	logz.Get(ctx).Warning(errorz.Errorf("user 123 not found", errorz.ID("not-found"), errorz.Status(404)))
+	logz.Get(ctx).Error(errorz.Errorf("default"))
+
+	require.Len(t, transport.events, 4)
+	require.Equal(t, []string{"entry"}, transport.events[0].Fingerprint)
+	require.Equal(t, []string{"explicit", "{{ default }}"}, transport.events[1].Fingerprint)
+	require.NotContains(t, transport.events[1].Extra, "fingerprint")
+	require.Equal(t, []string{"not-found", "404"}, transport.events[2].Fingerprint)
+	require.Nil(t, transport.events[3].Fingerprint)
+}
+
+func (s *ModuleSuite) TestTags(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	logz.Get(ctx).AddTag("scope", "scope-value")
+	logz.Get(ctx).Debug("message", logz.T("entry", "entry-value"), logz.M("k", "v"))
+	logz.Get(ctx).With(logz.T("bound", "bound-value")).Error(errorz.Errorf("test error", logz.ErrorTag("error", "error-value")))
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "desc")
+		defer releaseSpan()
+		logz.Get(ctx).AddTag("span", "span-value")
+	}()
+
+	require.Len(t, transport.events, 3)
+	require.Equal(t, map[string]string{"scope": "scope-value", "entry": "entry-value"}, transport.events[0].Tags)
+	require.Equal(t, map[string]interface{}{"k": "v"}, transport.events[0].Extra)
+	require.Equal(t, map[string]string{"scope": "scope-value", "bound": "bound-value", "error": "error-value"}, transport.events[1].Tags)
+	require.NotContains(t, transport.events[1].Extra, "error")
+	require.Equal(t, map[string]string{"scope": "scope-value", "span": "span-value"}, transport.events[2].Tags)
+
+	out := c.GetErrString()
+	require.Contains(t, out, `"entry":"entry-value","k":"v","level":"debug","msg":"message","scope":"scope-value"`)
+	require.Contains(t, out, `"bound":"bound-value","error":"error-value","level":"error","msg":"test error","scope":"scope-value"`)
+
+	transport.events = nil
+	fixturez.RequireNotPanics(t, func() {
+		logz.Get(ctx).Debug("message", logz.T("invalid key", "v"))
+	})
+	require.Len(t, transport.events, 1)
+	require.Equal(t, "v", transport.events[0].Tags["invalid_key"])
+}
+
+func (s *ModuleSuite) TestBreadcrumbs(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.SentryLevel = logz.Error
+		cfg.Breadcrumbs = true
+		cfg.MaxBreadcrumbs = 3
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("first")
+	logz.Get(ctx).Debug("second", logz.M("k", "v"))
+	logz.Get(ctx).Named("billing").Info("third")
+	logz.Get(ctx).Info("skipped", logz.AsBreadcrumb(false))
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "desc")
+		defer releaseSpan()
+		s.Clock.Mock.Add(time.Second)
+		logz.Get(ctx).AddMetadata("sk", "sv")
+	}()
+
+	logz.Get(ctx).Trace("trace")
+	logz.Get(ctx).Trace("forced trace", logz.AsBreadcrumb(true))
+	logz.Get(ctx).Error(errorz.Errorf("test error"))
+
+	require.Len(t, transport.events, 2)
+	require.Equal(t, "transaction", transport.events[0].Type)
+	require.Equal(t, []*sentry.Breadcrumb{
+		{
+			Type:      "default",
+			Category:  "log",
+			Message:   "second",
+			Data:      map[string]interface{}{"k": "v"},
+			Level:     sentry.LevelDebug,
+			Timestamp: s.Clock.Mock.Now().Add(-time.Second),
+		},
+		{
+			Type:      "default",
+			Category:  "billing",
+			Message:   "third",
+			Data:      map[string]interface{}{},
+			Level:     sentry.LevelInfo,
+			Timestamp: s.Clock.Mock.Now().Add(-time.Second),
+		},
+		{
+			Type:      "default",
+			Category:  "op",
+			Message:   "desc",
+			Data:      map[string]interface{}{"sk": "sv"},
+			Level:     sentry.LevelInfo,
+			Timestamp: s.Clock.Mock.Now(),
+		},
+	}, transport.events[1].Breadcrumbs)
+}
+
+func (s *ModuleSuite) TestBreadcrumbsOverride(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.SentryLevel = logz.Info
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("dropped")
+	logz.Get(ctx).Debug("breadcrumb", logz.AsBreadcrumb(true))
+	logz.Get(ctx).Info("event", logz.AsBreadcrumb(true))
+
+	func() {
+		_, releaseSpan := logz.Get(ctx).TraceSpan("op", "desc")
+		defer releaseSpan()
+	}()
+
+	logz.Get(ctx).Warning(errorz.Errorf("test error"))
+
+	require.Len(t, transport.events, 3)
+	require.Equal(t, "event", transport.events[0].Message)
+	require.Len(t, transport.events[2].Breadcrumbs, 1)
+	require.Equal(t, "breadcrumb", transport.events[2].Breadcrumbs[0].Message)
+}
+
+func (s *ModuleSuite) TestRedaction(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.Redaction = logz.NewDefaultRedactionConfig()
+		cfg.Redaction.BodyPaths = []string{"card.number"}
+	})
+	defer releaser()
+
+	func() {
+		req := httptest.NewRequest("POST", "/path?token=t", nil)
+		req.Header.Set("Authorization", "Bearer t")
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(req, []byte(`{"card":{"number":"4111"}}`))
+		defer releaseTransaction()
+
+		logz.Get(ctx).Debug("signup for %v", logz.A("a@b.com"), logz.M("password", "p"))
+	}()
+
+	require.Len(t, transport.events, 2)
+	require.Equal(t, "signup for [Filtered]", transport.events[0].Message)
+	require.Equal(t, "[Filtered]", transport.events[0].Extra["password"])
+	require.Equal(t, "[Filtered]", transport.events[0].Request.Headers["Authorization"])
+	require.Equal(t, "token=[Filtered]", transport.events[0].Request.QueryString)
+	require.Equal(t, `{"card":{"number":"[Filtered]"}}`, transport.events[0].Request.Data)
+	require.Equal(t, "[Filtered]", transport.events[1].Request.Headers["Authorization"])
+
+	out := c.GetErrString()
+	require.NotContains(t, out, "a@b.com")
+	require.Contains(t, out, `"msg":"signup for [Filtered]","password":"[Filtered]"`)
+}
+
+func (s *ModuleSuite) TestBeforeSend(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.BeforeSend = func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+			require.NotNil(t, hint)
+			if event.Message == "drop" {
+				return nil
+			}
+			event.Tags["hook"] = "beforeSend"
+			return event
+		}
+		cfg.BeforeSendTransaction = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			require.NotNil(t, event.Request)
+			if event.Transaction == "GET /drop" {
+				return nil
+			}
+			event.Tags = map[string]string{"hook": "beforeSendTransaction"}
+			return event
+		}
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("keep")
+	logz.Get(ctx).Debug("drop")
+
+	for _, path := range []string{"/keep", "/drop"} {
+		func() {
+			_, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServerSimple(&sentry.Request{Method: "GET", URL: "http://example.com" + path})
+			releaseTransaction()
+		}()
+	}
+
+	require.Len(t, transport.events, 2)
+	require.Equal(t, "keep", transport.events[0].Message)
+	require.Equal(t, "beforeSend", transport.events[0].Tags["hook"])
+	require.Equal(t, "GET /keep", transport.events[1].Transaction)
+	require.Equal(t, "beforeSendTransaction", transport.events[1].Tags["hook"])
+
+	out := c.GetErrString()
+	require.Contains(t, out, `"msg":"keep"`)
+	require.NotContains(t, out, `"msg":"drop"`)
+}
+
+func (s *ModuleSuite) TestBeforeSendOutputDroppedEvents(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.BeforeSend = func(_ *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			return nil
+		}
+		cfg.OutputDroppedEvents = true
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("drop")
+	logz.Get(ctx).Error(errorz.Errorf("drop error"))
+	require.Empty(t, transport.events)
+
+	out := c.GetErrString()
+	require.Contains(t, out, `"msg":"drop"`)
+	require.Contains(t, out, `"msg":"drop error"`)
+}
+
+func (s *ModuleSuite) TestNamed(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Info
+		cfg.SentryLevel = logz.Error
+		cfg.OutputLevelOverrides = map[string]logz.Level{
+			"billing":          logz.Debug,
+			"billing.invoices": logz.Warning,
+		}
+		cfg.SentryLevelOverrides = map[string]logz.Level{
+			"billing": logz.Debug,
+		}
+	})
+	defer releaser()
+
+	logz.Get(ctx).Named("billing").Named("invoices").Debug("invoices message")
+	logz.Get(ctx).Named("billing").Debug("billing message")
+	logz.Get(ctx).Named("other").With(logz.M("k", "v")).Named("sub").Debug("other message")
+	logz.Get(ctx).Named("other").Error(errorz.Errorf("other error"))
+
+	require.Len(t, transport.events, 3)
+	require.Equal(t, "invoices message", transport.events[0].Message)
+	require.Equal(t, map[string]string{"logger": "billing.invoices"}, transport.events[0].Tags)
+	require.Equal(t, "billing message", transport.events[1].Message)
+	require.Equal(t, map[string]string{"logger": "billing"}, transport.events[1].Tags)
+	require.Equal(t, "other error", transport.events[2].Exception[0].Value)
+	require.Equal(t, map[string]string{"logger": "other"}, transport.events[2].Tags)
+
+	out := c.GetErrString()
+	require.NotContains(t, out, "invoices message")
+	require.Contains(t, out, `"logger":"billing","msg":"billing message"`)
+	require.NotContains(t, out, "other message")
+	require.Contains(t, out, `"logger":"other","msg":"other error"`)
+}
+
 func (s *ModuleSuite) TestTracing(ctx context.Context, t *testing.T) {
 	ctx, releaser, transport := setupLogs(ctx)
 	defer releaser()
@@ -343,6 +678,7 @@ func (s *ModuleSuite) TestTracing(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Spans, 1)
 	span := event.Spans[0]
@@ -438,6 +774,7 @@ func (s *ModuleSuite) TestTracingSimple(ctx context.Context, t *testing.T) {
 
 	require.NotEmpty(t, event.Sdk)
 	event.Sdk = sentry.SdkInfo{}
+	event.Modules = nil
 
 	require.Len(t, event.Spans, 1)
 	span := event.Spans[0]
@@ -484,12 +821,363 @@ func (s *ModuleSuite) TestTracingSimple(ctx context.Context, t *testing.T) {
 	}, event)
 }
 
+func (s *ModuleSuite) TestTraceTransaction(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+	startTime := clockz.Get(ctx).Now()
+
+	var parentSpan *sentry.Span
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "parent")
+		defer releaseSpan()
+		parentSpan = sentry.TransactionFromContext(ctx)
+
+		jobCtx, finish := logz.Get(ctx).TraceTransaction("queue.process", "billing.charge",
+			logz.ContinueFrom(map[string]string{"Sentry-Trace": "0123456789abcdef0123456789abcdef-0123456789abcdef-1"}))
+		require.NotSame(t, sentry.GetHubFromContext(ctx), sentry.GetHubFromContext(jobCtx))
+		logz.Get(jobCtx).AddMetadata("k", "v")
+		s.Clock.Mock.Add(time.Second)
+		finish(errorz.Errorf("job error"))
+	}()
+
+	require.Len(t, transport.events, 3)
+
+	require.Equal(t, "job error", transport.events[0].Exception[0].Value)
+	require.Equal(t, "v", transport.events[0].Extra["k"])
+	errorTrace := transport.events[0].Contexts["trace"].(*sentry.TraceContext)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", errorTrace.TraceID.String())
+
+	job := transport.events[1]
+	require.Equal(t, "transaction", job.Type)
+	require.Equal(t, "billing.charge", job.Transaction)
+	require.Equal(t, startTime, job.StartTime)
+	require.Equal(t, startTime.Add(time.Second), job.Timestamp)
+	require.Equal(t, map[string]interface{}{"k": "v"}, job.Extra)
+	jobTrace := job.Contexts["trace"].(*sentry.TraceContext)
+	require.Equal(t, "queue.process", jobTrace.Op)
+	require.Equal(t, sentry.SpanStatusInternalError, jobTrace.Status)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", jobTrace.TraceID.String())
+	require.Equal(t, "0123456789abcdef", jobTrace.ParentSpanID.String())
+	require.Equal(t, errorTrace.SpanID, jobTrace.SpanID)
+
+	require.Equal(t, "transaction", transport.events[2].Type)
+	require.Empty(t, transport.events[2].Spans)
+	require.Empty(t, transport.events[2].Extra)
+	require.NotEqual(t, parentSpan.TraceID, jobTrace.TraceID)
+}
+
+func (s *ModuleSuite) TestTraceTransactionStatus(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	_, finish := logz.Get(ctx).TraceTransaction("cron", "cleanup")
+	finish(nil)
+	_, finish = logz.Get(ctx).TraceTransaction("cron", "cleanup")
+	finish(errorz.Errorf("not found", errorz.Status(http.StatusNotFound)))
+	_, finish = logz.Get(ctx).TraceTransaction("cron", "cleanup")
+	finish(errorz.Wrap(context.DeadlineExceeded))
+
+	require.Len(t, transport.events, 5)
+	require.Equal(t, sentry.SpanStatusOK, transport.events[0].Contexts["trace"].(*sentry.TraceContext).Status)
+	require.Equal(t, sentry.SpanID{}, transport.events[0].Contexts["trace"].(*sentry.TraceContext).ParentSpanID)
+	require.Equal(t, "not found", transport.events[1].Exception[0].Value)
+	require.Equal(t, sentry.SpanStatusNotFound, transport.events[2].Contexts["trace"].(*sentry.TraceContext).Status)
+	require.Equal(t, sentry.SpanStatusDeadlineExceeded, transport.events[4].Contexts["trace"].(*sentry.TraceContext).Status)
+}
+
+func (s *ModuleSuite) TestTraceFields(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	var span *sentry.Span
+
+	func() {
+		ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "desc")
+		defer releaseSpan()
+		span = sentry.TransactionFromContext(ctx)
+
+		logz.Get(ctx).Info("span message")
+		logz.Get(ctx).Error(errorz.Errorf("span error"))
+	}()
+
+	logz.Get(ctx).Info("no span message")
+
+	require.Len(t, transport.events, 4)
+	for _, event := range transport.events {
+		require.NotContains(t, event.Contexts, "golang-inject-logs-span")
+	}
+
+	lines := strings.Split(strings.TrimSpace(c.GetErrString()), "\n")
+	require.Len(t, lines, 4)
+	for _, line := range lines[:2] {
+		require.Contains(t, line, `"sampled":true`)
+		require.Contains(t, line, fmt.Sprintf(`"span_id":"%v"`, span.SpanID))
+		require.Contains(t, line, fmt.Sprintf(`"trace_id":"%v"`, span.TraceID))
+	}
+	require.NotContains(t, lines[3], "trace_id")
+	require.Contains(t, lines[3], "no span message")
+}
+
+func (s *ModuleSuite) TestTraceFieldsConfig(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, _ := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.TraceFields = &logz.TraceFields{
+			TraceID: "dd.trace_id",
+			SpanID:  "dd.span_id",
+		}
+	})
+	defer releaser()
+
+	ctx, releaseSpan := logz.Get(ctx).TraceSpan("op", "desc")
+	logz.Get(ctx).Debug("span message")
+	span := sentry.TransactionFromContext(ctx)
+	releaseSpan()
+
+	out := c.GetErrString()
+	require.Contains(t, out, fmt.Sprintf(`"dd.span_id":"%v","dd.trace_id":"%v"`, span.SpanID, span.TraceID))
+	require.NotContains(t, out, "sampled")
+}
+
+func (s *ModuleSuite) TestStartSpan(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	func() {
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(httptest.NewRequest("GET", "/path", nil), nil)
+		defer releaseTransaction()
+
+		_, span := logz.Get(ctx).StartSpan("db.query", "select")
+		span.SetTag("db.system", "postgresql")
+		span.SetData("k", "v")
+		span.SetStatus(sentry.SpanStatusAborted)
+		span.Finish()
+
+		_, span = logz.Get(ctx).StartSpan("db.query", "insert")
+		span.RecordError(errorz.Errorf("conflict", errorz.Status(http.StatusConflict)))
+		span.Finish()
+
+		_, span = logz.Get(ctx).StartSpan("db.query", "update")
+		span.FinishWithError(errorz.Wrap(context.Canceled))
+
+		_, span = logz.Get(ctx).StartSpan("db.query", "delete")
+		span.FinishWithError(nil)
+
+		_, span = logz.Get(ctx).StartSpan("db.query", "upsert")
+		fixturez.RequireNotPanics(t, func() { span.RecordError(nil) })
+		span.Finish()
+	}()
+
+	require.Len(t, transport.events, 3)
+
+	transaction := transport.events[2]
+	require.Equal(t, "transaction", transaction.Type)
+	require.Len(t, transaction.Spans, 5)
+
+	require.Equal(t, sentry.SpanStatusAborted, transaction.Spans[0].Status)
+	require.Equal(t, map[string]string{"db.system": "postgresql"}, transaction.Spans[0].Tags)
+	require.Equal(t, map[string]interface{}{"k": "v"}, transaction.Spans[0].Data)
+
+	require.Equal(t, sentry.SpanStatusAlreadyExists, transaction.Spans[1].Status)
+	require.Equal(t, map[string]interface{}{"error": "conflict"}, transaction.Spans[1].Data)
+	require.Equal(t, "conflict", transport.events[0].Exception[0].Value)
+	errorTrace := transport.events[0].Contexts["trace"].(*sentry.TraceContext)
+	require.Equal(t, transaction.Spans[1].SpanID, errorTrace.SpanID)
+	require.Equal(t, transaction.Spans[1].TraceID, errorTrace.TraceID)
+	require.Equal(t, "db.query", errorTrace.Op)
+
+	require.Equal(t, sentry.SpanStatusCanceled, transaction.Spans[2].Status)
+	require.Equal(t, context.Canceled.Error(), transport.events[1].Exception[0].Value)
+	require.Equal(t, transaction.Spans[2].SpanID, transport.events[1].Contexts["trace"].(*sentry.TraceContext).SpanID)
+
+	require.Equal(t, sentry.SpanStatusOK, transaction.Spans[3].Status)
+	require.Equal(t, transaction.Contexts["trace"].(*sentry.TraceContext).SpanID, transaction.Spans[3].ParentSpanID)
+
+	require.Equal(t, sentry.SpanStatusUndefined, transaction.Spans[4].Status)
+	require.NotContains(t, transaction.Spans[4].Data, "error")
+}
+
+func (s *ModuleSuite) TestLevelFiltering(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	var processed []string
+
+	ctx, releaser, _ := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Warning
+		cfg.SentryLevel = logz.Error
+		cfg.OutputLevelOverrides = map[string]logz.Level{"verbose": logz.Debug}
+		cfg.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			if len(event.Exception) > 0 {
+				processed = append(processed, event.Exception[0].Value)
+			} else {
+				processed = append(processed, event.Message)
+			}
+			return event
+		}
+	})
+	defer releaser()
+
+	logz.Get(ctx).Debug("filtered debug")
+	logz.Get(ctx).Info("filtered info")
+	logz.Get(ctx).Named("verbose").Debug("verbose debug")
+	logz.Get(ctx).Warning(errorz.Errorf("warning"))
+	logz.Get(ctx).Error(errorz.Errorf("error"))
+
+	require.Equal(t, []string{"verbose debug", "warning", "error"}, processed)
+
+	fixturez.RequireNoError(t, logz.Get(ctx).SetLevels(&logz.Levels{OutputLevel: logz.Error, SentryLevel: logz.Error}, 0))
+	processed = nil
+	logz.Get(ctx).Warning(errorz.Errorf("warning"))
+	require.Empty(t, processed)
+}
+
+func (s *ModuleSuite) TestTraceLevel(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Trace
+		cfg.Breadcrumbs = true
+	})
+	defer releaser()
+
+	require.True(t, logz.Get(ctx).Enabled(logz.Trace))
+	logz.Get(ctx).Trace("trace message: %v", logz.A("v"))
+	require.Empty(t, transport.events)
+	require.Contains(t, c.GetErrString(), `"level":"trace","msg":"trace message: v"`)
+
+	logz.Get(ctx).Error(errorz.Errorf("error message"))
+	require.Len(t, transport.events, 1)
+	require.Empty(t, transport.events[0].Breadcrumbs)
+
+	require.Error(t, (&logz.Levels{OutputLevel: logz.Trace, SentryLevel: logz.Trace}).Validate())
+}
+
+func (s *ModuleSuite) TestFatal(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	var codes []int
+	var events []*sentry.Event
+
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.ExitFunc = func(code int) {
+			codes = append(codes, code)
+		}
+		cfg.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			events = append(events, event)
+			return event
+		}
+	})
+	defer releaser()
+
+	logz.Get(ctx).Fatal(errorz.Errorf("fatal message"))
+	require.Equal(t, []int{1}, codes)
+	require.Len(t, events, 1)
+	require.Equal(t, sentry.LevelFatal, events[0].Level)
+	require.True(t, transport.isFlushed)
+	require.Contains(t, c.GetErrString(), `"level":"fatal"`)
+}
+
+func (s *ModuleSuite) TestStackCapture(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+
+	for _, stackCapture := range []logz.StackCapture{"", logz.StackCaptureOff, logz.StackCaptureSentry, logz.StackCaptureAlways} {
+		var events []*sentry.Event
+
+		ctx, releaser, _ := setupLogs(ctx, func(cfg *logz.Config) {
+			cfg.SentryLevel = logz.Info
+			cfg.StackCapture = stackCapture
+			cfg.MaxStackDepth = 2
+			cfg.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+				events = append(events, event)
+				return event
+			}
+		})
+
+		logz.Get(ctx).Debug("debug")
+		logz.Get(ctx).Info("info")
+		releaser()
+
+		require.Len(t, events, 2, stackCapture)
+
+		switch stackCapture {
+		case logz.StackCaptureOff:
+			require.Empty(t, events[0].Threads)
+			require.Empty(t, events[1].Threads)
+		case logz.StackCaptureSentry:
+			require.Empty(t, events[0].Threads)
+			require.Len(t, events[1].Threads[0].Stacktrace.Frames, 2)
+		default:
+			require.Len(t, events[0].Threads[0].Stacktrace.Frames, 2)
+			require.Len(t, events[1].Threads[0].Stacktrace.Frames, 2)
+			require.Equal(t, "(*ModuleSuite).TestStackCapture", events[1].Threads[0].Stacktrace.Frames[1].Function)
+		}
+	}
+
+	require.Error(t, (&logz.Config{StackCapture: "unknown"}).Validate())
+}
+
+func (s *ModuleSuite) TestEnabledLazy(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Warning
+		cfg.SentryLevel = logz.Info
+		cfg.OutputLevelOverrides = map[string]logz.Level{"verbose": logz.Debug}
+	})
+	defer releaser()
+
+	require.False(t, logz.Get(ctx).Enabled(logz.Debug))
+	require.True(t, logz.Get(ctx).Enabled(logz.Info))
+	require.True(t, logz.Get(ctx).Enabled(logz.Error))
+	require.True(t, logz.Get(ctx).Named("verbose").Enabled(logz.Debug))
+	require.True(t, logz.Get(ctx).With(logz.AsBreadcrumb(true)).Enabled(logz.Debug))
+
+	calls := 0
+	options := []logz.Option{
+		logz.LazyA(func() logz.Args {
+			calls++
+			return logz.A("v2")
+		}),
+		logz.Lazy(func() logz.Metadata {
+			calls++
+			return logz.Metadata{"k": "v"}
+		}),
+	}
+
+	logz.Get(ctx).Debug("message: %v %v", append([]logz.Option{logz.A("v1")}, options...)...)
+	require.Equal(t, 0, calls)
+	require.Empty(t, transport.events)
+
+	logz.Get(ctx).Info("message: %v %v", append([]logz.Option{logz.A("v1")}, options...)...)
+	require.Equal(t, 2, calls)
+	require.Len(t, transport.events, 1)
+	require.Equal(t, "message: v1 v2", transport.events[0].Message)
+	require.Equal(t, map[string]interface{}{"k": "v"}, transport.events[0].Extra)
+
+	logz.Get(ctx).Named("verbose").Debug("message: %v %v", append([]logz.Option{logz.A("v1")}, options...)...)
+	require.Equal(t, 4, calls)
+	require.Contains(t, c.GetErrString(), `"k":"v","level":"debug","logger":"verbose","msg":"message: v1 v2"`)
+
+	require.False(t, logz.Get(context.Background()).Enabled(logz.Error))
+}
+
 func (s *ModuleSuite) TestNoopLogs(_ context.Context, t *testing.T) {
 	c := fixturez.CaptureOutput()
 	defer c.Close()
 
 	fixturez.RequireNotPanics(t, func() {
 		noopLogs := logz.Get(context.Background())
+		noopLogs.Trace("message")
 		noopLogs.Debug("message")
 		noopLogs.Info("message")
 		noopLogs.Warning(errorz.Errorf("error"))
@@ -500,8 +1188,23 @@ func (s *ModuleSuite) TestNoopLogs(_ context.Context, t *testing.T) {
 		releaser()
 		_, releaser = noopLogs.TraceHTTPRequestServerSimple(nil)
 		releaser()
+		_, finish := noopLogs.TraceTransaction("op", "name")
+		finish(errorz.Errorf("error"))
+		_, span := noopLogs.StartSpan("op", "desc")
+		span.SetStatus(sentry.SpanStatusOK)
+		span.SetTag("k", "v")
+		span.SetData("k", "v")
+		span.RecordError(errorz.Errorf("error"))
+		span.Finish()
+		span.FinishWithError(errorz.Errorf("error"))
 		noopLogs.SetUser(nil)
 		noopLogs.AddMetadata("k", "v")
+		noopLogs.AddTag("k", "v")
+		noopLogs.With(logz.M("k", "v")).Debug("message")
+		noopLogs.Named("name").Debug("message")
+		require.Nil(t, noopLogs.GetLevels())
+		fixturez.RequireNoError(t, noopLogs.SetLevels(&logz.Levels{}, time.Second))
+		noopLogs.ResetLevels()
 	})
 
 	require.Empty(t, c.GetErr())
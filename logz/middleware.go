@@ -0,0 +1,215 @@
+package logz
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	httpStatusCodeKey          = "http.status_code"
+	httpResponseContentLength  = "http.response_content_length"
+	defaultMiddlewareBodyBytes = sentryMaxRequestBodyBytes
+)
+
+var (
+	_ http.ResponseWriter = &responseRecorder{}
+	_ http.Flusher        = &responseRecorder{}
+	_ http.Hijacker       = &responseRecorder{}
+)
+
+// MiddlewareOption describes an option which can be applied to the HTTP middleware.
+type MiddlewareOption func(c *middlewareConfig)
+
+type middlewareConfig struct {
+	maxBodyBytes  int64
+	routeNameFunc func(req *http.Request) string
+	skipPaths     map[string]struct{}
+}
+
+// MaxBodyBytes sets the maximum number of request body bytes attached to the transaction, zero disables body capture.
+// Bodies exceeding the limit are not attached at all.
+func MaxBodyBytes(maxBodyBytes int64) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.maxBodyBytes = maxBodyBytes
+	}
+}
+
+// RouteName sets a function used to resolve the transaction name once the request has been served, for example from
+// the route matched by a router. Returning an empty string keeps the default "METHOD /path" name.
+func RouteName(routeNameFunc func(req *http.Request) string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.routeNameFunc = routeNameFunc
+	}
+}
+
+// SkipPaths disables tracing for requests matching exactly one of the given paths, for example "/healthz".
+func SkipPaths(paths ...string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		for _, path := range paths {
+			c.skipPaths[path] = struct{}{}
+		}
+	}
+}
+
+// NewMiddleware returns a net/http middleware which traces inbound requests using TraceHTTPRequestServer.
+// The Logs are extracted from the request context, panics are recovered and reported as errors.
+func NewMiddleware(options ...MiddlewareOption) func(http.Handler) http.Handler {
+	c := &middlewareConfig{
+		maxBodyBytes: defaultMiddlewareBodyBytes,
+		skipPaths:    make(map[string]struct{}),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if _, ok := c.skipPaths[req.URL.Path]; ok {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			reqBody := c.readBody(req)
+			traceReq := req
+			if reqBody == nil {
+				traceReq = req.WithContext(req.Context()) // shallow copy, so that Sentry can't capture the body lazily
+				traceReq.Body = http.NoBody
+			}
+
+			ctx, finishSpan := Get(req.Context()).TraceHTTPRequestServer(traceReq, reqBody)
+			req = req.WithContext(ctx)
+			rec := &responseRecorder{ResponseWriter: w}
+
+			defer func() {
+				r := recover()
+
+				if r != nil && r != http.ErrAbortHandler {
+					Get(ctx).Error(wrapPanic(r))
+
+					if !rec.wroteHeader {
+						rec.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+
+				if span := sentry.TransactionFromContext(ctx); span != nil {
+					statusCode := rec.getStatusCode()
+					span.Status = httpStatusToSpanStatus(statusCode)
+					span.SetTag(httpStatusCodeKey, strconv.Itoa(statusCode))
+
+					if r != nil {
+						span.Status = sentry.SpanStatusInternalError
+					}
+
+					if span.Data == nil {
+						span.Data = make(map[string]interface{})
+					}
+					span.Data[httpResponseContentLength] = rec.size
+				}
+
+				if c.routeNameFunc != nil {
+					if routeName := c.routeNameFunc(req); routeName != "" {
+						sentry.GetHubFromContext(ctx).Scope().SetTransaction(routeName)
+					}
+				}
+
+				finishSpan()
+
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+			}()
+
+			next.ServeHTTP(rec, req)
+		})
+	}
+}
+
+// readBody reads a size-limited copy of the request body, restoring the original body for the next handler.
+// It returns nil if the body is not captured, in which case no request body is set on the scope.
+func (c *middlewareConfig) readBody(req *http.Request) []byte {
+	if c.maxBodyBytes <= 0 || req.Body == nil || req.Body == http.NoBody || req.ContentLength > c.maxBodyBytes {
+		return nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(req.Body, c.maxBodyBytes+1))
+	req.Body = &readCloser{
+		Reader: io.MultiReader(bytes.NewReader(buf), req.Body),
+		Closer: req.Body,
+	}
+
+	if err != nil || int64(len(buf)) > c.maxBodyBytes {
+		return nil
+	}
+
+	return buf
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	size        int64
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (r *responseRecorder) Write(buf []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	n, err := r.ResponseWriter.Write(buf)
+	r.size += int64(n)
+	return n, err
+}
+
+// Flush implements the http.Flusher interface.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if !r.wroteHeader {
+			r.WriteHeader(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := r.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errorz.Errorf("hijacking not supported", errorz.Skip())
+}
+
+// Unwrap returns the wrapped http.ResponseWriter.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *responseRecorder) getStatusCode() int {
+	if !r.wroteHeader {
+		return http.StatusOK
+	}
+	return r.statusCode
+}
@@ -0,0 +1,115 @@
+package logz_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type MiddlewareSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestMiddleware(t *testing.T) {
+	fixturez.RunSuite(t, &MiddlewareSuite{})
+}
+
+func serveMiddleware(ctx context.Context, h http.HandlerFunc, req *http.Request, options ...logz.MiddlewareOption) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	logz.NewMiddleware(options...)(h).ServeHTTP(rec, req.WithContext(ctx))
+	return rec
+}
+
+func (s *MiddlewareSuite) TestMiddleware(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	rec := serveMiddleware(ctx, func(w http.ResponseWriter, r *http.Request) {
+		buf, err := io.ReadAll(r.Body)
+		fixturez.RequireNoError(t, err)
+		require.Equal(t, "body", string(buf))
+		require.NotNil(t, sentry.TransactionFromContext(r.Context()))
+
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write([]byte("created"))
+		fixturez.RequireNoError(t, err)
+	}, httptest.NewRequest("POST", "/path", strings.NewReader("body")))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "created", rec.Body.String())
+
+	require.Len(t, transport.events, 1)
+	event := transport.events[0]
+	require.Equal(t, "transaction", event.Type)
+	require.Equal(t, "POST /path", event.Transaction)
+	require.Equal(t, "body", event.Request.Data)
+	require.Equal(t, map[string]string{"http.status_code": "201"}, event.Tags)
+	require.Equal(t, int64(7), event.Extra["http.response_content_length"])
+	require.Equal(t, sentry.SpanStatusOK, event.Contexts["trace"].(*sentry.TraceContext).Status)
+}
+
+func (s *MiddlewareSuite) TestMiddlewareOptions(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	rec := serveMiddleware(ctx, func(w http.ResponseWriter, r *http.Request) {
+		buf, err := io.ReadAll(r.Body)
+		fixturez.RequireNoError(t, err)
+		require.Equal(t, "body", string(buf))
+		w.WriteHeader(http.StatusNotFound)
+	}, httptest.NewRequest("POST", "/users/1", strings.NewReader("body")),
+		logz.MaxBodyBytes(2),
+		logz.RouteName(func(_ *http.Request) string { return "POST /users/{id}" }))
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Len(t, transport.events, 1)
+	event := transport.events[0]
+	require.Equal(t, "POST /users/{id}", event.Transaction)
+	require.Empty(t, event.Request.Data)
+	require.Equal(t, sentry.SpanStatusNotFound, event.Contexts["trace"].(*sentry.TraceContext).Status)
+
+	transport.events = nil
+	rec = serveMiddleware(ctx, func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, sentry.TransactionFromContext(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	}, httptest.NewRequest("GET", "/healthz", nil), logz.SkipPaths("/healthz"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, transport.events)
+}
+
+func (s *MiddlewareSuite) TestMiddlewarePanic(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	rec := serveMiddleware(ctx, func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	}, httptest.NewRequest("GET", "/path", nil))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Len(t, transport.events, 2)
+	require.Equal(t, sentry.LevelError, transport.events[0].Level)
+	require.Equal(t, "test panic", transport.events[0].Exception[0].Value)
+	require.Equal(t, "transaction", transport.events[1].Type)
+	require.Equal(t, sentry.SpanStatusInternalError, transport.events[1].Contexts["trace"].(*sentry.TraceContext).Status)
+	require.Contains(t, c.GetErrString(), "test panic")
+
+	fixturez.RequirePanicsWith(t, http.ErrAbortHandler.Error(), func() {
+		serveMiddleware(ctx, func(_ http.ResponseWriter, _ *http.Request) {
+			panic(http.ErrAbortHandler)
+		}, httptest.NewRequest("GET", "/path", nil))
+	})
+}
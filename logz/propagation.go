@@ -0,0 +1,384 @@
+package logz
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	traceParentHeader     = "traceparent"
+	traceStateHeader      = "tracestate"
+	baggageHeader         = "baggage"
+	b3Header              = "b3"
+	b3TraceIDHeader       = "X-B3-TraceId"
+	b3SpanIDHeader        = "X-B3-SpanId"
+	b3SampledHeader       = "X-B3-Sampled"
+	b3FlagsHeader         = "X-B3-Flags"
+	maxBaggageBytes       = 8192
+	maxBaggageMembers     = 180
+	traceParentSampledBit = 0x01
+)
+
+var (
+	_ carrier = headerCarrier{}
+	_ carrier = mapCarrier{}
+
+	traceParentRegexp   = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})(-.*)?$`)
+	b3TraceIDRegexp     = regexp.MustCompile(`^(?:[0-9a-f]{16}){1,2}$`)
+	b3SpanIDRegexp      = regexp.MustCompile(`^[0-9a-f]{16}$`)
+	baggageMemberRegexp = regexp.MustCompile(`^[!#$%&'*+\-.^_` + "`" + `|~0-9A-Za-z]+\s*=\s*[^\s,;"\\]*\s*(;.*)?$`)
+
+	defaultPropagators = []Propagator{SentryPropagator}
+)
+
+// Propagator describes a trace context propagation format.
+type Propagator string
+
+// Known propagators.
+const (
+	SentryPropagator  Propagator = "sentry"
+	W3CPropagator     Propagator = "w3c"
+	BaggagePropagator Propagator = "baggage"
+	B3Propagator      Propagator = "b3"
+	B3MultiPropagator Propagator = "b3multi"
+)
+
+// extract reads the trace context from the carrier into tc, trace and span IDs are only set if not already present.
+func (p Propagator) extract(c carrier, tc *traceContext) {
+	switch p {
+	case SentryPropagator:
+		if tc.isValid {
+			return
+		}
+		if traceID, spanID, sampled, ok := parseSentryTraceHeader(c.get(sentryTraceHeader)); ok {
+			tc.setIDs(traceID, spanID, sampled)
+		}
+	case W3CPropagator:
+		if tc.isValid {
+			return
+		}
+		if traceID, spanID, sampled, ok := parseTraceParentHeader(c.get(traceParentHeader)); ok {
+			tc.setIDs(traceID, spanID, sampled)
+			tc.traceState = strings.TrimSpace(c.get(traceStateHeader))
+		}
+	case BaggagePropagator:
+		tc.baggage = parseBaggageHeader(c.get(baggageHeader))
+	case B3Propagator, B3MultiPropagator:
+		if tc.isValid {
+			return
+		}
+		if traceID, spanID, sampled, ok := parseB3SingleHeader(c.get(b3Header)); ok {
+			tc.setIDs(traceID, spanID, sampled)
+			return
+		}
+		if traceID, spanID, sampled, ok := parseB3MultiHeaders(c); ok {
+			tc.setIDs(traceID, spanID, sampled)
+		}
+	default:
+		panic(errorz.Errorf("unknown propagator: %v", errorz.A(p), errorz.SkipPackage()))
+	}
+}
+
+// inject writes the trace context from tc into the carrier.
+func (p Propagator) inject(c carrier, tc *traceContext) {
+	switch p {
+	case SentryPropagator:
+		if tc.isValid {
+			c.set(sentryTraceHeader, formatSentryTraceHeader(tc.traceID, tc.spanID, tc.sampled))
+		}
+	case W3CPropagator:
+		if tc.isValid {
+			c.set(traceParentHeader, formatTraceParentHeader(tc.traceID, tc.spanID, tc.sampled))
+			if tc.traceState != "" {
+				c.set(traceStateHeader, tc.traceState)
+			}
+		}
+	case BaggagePropagator:
+		if len(tc.baggage) > 0 {
+			c.set(baggageHeader, strings.Join(tc.baggage, ","))
+		}
+	case B3Propagator:
+		if tc.isValid {
+			c.set(b3Header, formatB3SingleHeader(tc.traceID, tc.spanID, tc.sampled))
+		}
+	case B3MultiPropagator:
+		if tc.isValid {
+			c.set(b3TraceIDHeader, tc.traceID.String())
+			c.set(b3SpanIDHeader, tc.spanID.String())
+			if sampled := formatB3Sampled(tc.sampled); sampled != "" {
+				c.set(b3SampledHeader, sampled)
+			}
+		}
+	default:
+		panic(errorz.Errorf("unknown propagator: %v", errorz.A(p), errorz.SkipPackage()))
+	}
+}
+
+type carrier interface {
+	get(k string) string
+	set(k, v string)
+}
+
+type headerCarrier http.Header
+
+// get implements the carrier interface.
+func (c headerCarrier) get(k string) string {
+	return http.Header(c).Get(k)
+}
+
+// set implements the carrier interface.
+func (c headerCarrier) set(k, v string) {
+	http.Header(c).Set(k, v)
+}
+
+type mapCarrier map[string]string
+
+// get implements the carrier interface.
+func (c mapCarrier) get(k string) string {
+	if v, ok := c[k]; ok {
+		return v
+	}
+	for ck, v := range c {
+		if strings.EqualFold(ck, k) {
+			return v
+		}
+	}
+	return ""
+}
+
+// set implements the carrier interface.
+func (c mapCarrier) set(k, v string) {
+	c[k] = v
+}
+
+// traceContext describes the propagated state of a trace.
+type traceContext struct {
+	isValid    bool
+	traceID    sentry.TraceID
+	spanID     sentry.SpanID
+	sampled    sentry.Sampled
+	traceState string
+	baggage    []string
+}
+
+func (tc *traceContext) setIDs(traceID sentry.TraceID, spanID sentry.SpanID, sampled sentry.Sampled) {
+	tc.isValid = true
+	tc.traceID = traceID
+	tc.spanID = spanID
+	tc.sampled = sampled
+}
+
+// toContext stores the parts of the trace context which are not tracked by Sentry spans on the context.
+func (tc *traceContext) toContext(ctx context.Context) context.Context {
+	if tc.traceState == "" && len(tc.baggage) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, logsPropagationContextKey, &traceContext{
+		traceState: tc.traceState,
+		baggage:    tc.baggage,
+	})
+}
+
+func extractTraceContext(propagators []Propagator, c carrier) *traceContext {
+	tc := &traceContext{}
+	for _, p := range propagators {
+		p.extract(c, tc)
+	}
+	return tc
+}
+
+func injectTraceContext(propagators []Propagator, c carrier, tc *traceContext) {
+	for _, p := range propagators {
+		p.inject(c, tc)
+	}
+}
+
+// newSpanTraceContext builds a trace context for propagating the given span downstream.
+func newSpanTraceContext(ctx context.Context, span *sentry.Span) *traceContext {
+	tc := &traceContext{}
+	tc.setIDs(span.TraceID, span.SpanID, span.Sampled)
+
+	if propagated, ok := ctx.Value(logsPropagationContextKey).(*traceContext); ok {
+		tc.traceState = propagated.traceState
+		tc.baggage = propagated.baggage
+	}
+
+	return tc
+}
+
+func newTraceSpanOption(tc *traceContext) sentry.SpanOption {
+	return func(span *sentry.Span) {
+		if tc.isValid {
+			span.TraceID = tc.traceID
+			span.ParentSpanID = tc.spanID
+			span.Sampled = tc.sampled
+		}
+	}
+}
+
+func getPropagators(ctx context.Context) []Propagator {
+	if l, ok := ctx.Value(logsContextKey).(*logsImpl); ok {
+		return l.propagators
+	}
+	return defaultPropagators
+}
+
+func formatSentryTraceHeader(traceID sentry.TraceID, spanID sentry.SpanID, sampled sentry.Sampled) string {
+	switch sampled {
+	case sentry.SampledTrue:
+		return fmt.Sprintf("%v-%v-1", traceID, spanID)
+	case sentry.SampledFalse:
+		return fmt.Sprintf("%v-%v-0", traceID, spanID)
+	default:
+		return fmt.Sprintf("%v-%v", traceID, spanID)
+	}
+}
+
+func parseTraceParentHeader(value string) (sentry.TraceID, sentry.SpanID, sentry.Sampled, bool) {
+	var traceID sentry.TraceID
+	var parentSpanID sentry.SpanID
+
+	m := traceParentRegexp.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil || m[1] == "ff" || (m[1] == "00" && m[5] != "") {
+		return traceID, parentSpanID, sentry.SampledFalse, false
+	}
+
+	_, _ = hex.Decode(traceID[:], []byte(m[2]))
+	_, _ = hex.Decode(parentSpanID[:], []byte(m[3]))
+
+	if traceID == (sentry.TraceID{}) || parentSpanID == (sentry.SpanID{}) {
+		return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+	}
+
+	var flags [1]byte
+	_, _ = hex.Decode(flags[:], []byte(m[4]))
+
+	if flags[0]&traceParentSampledBit != 0 {
+		return traceID, parentSpanID, sentry.SampledTrue, true
+	}
+	return traceID, parentSpanID, sentry.SampledFalse, true
+}
+
+func formatTraceParentHeader(traceID sentry.TraceID, spanID sentry.SpanID, sampled sentry.Sampled) string {
+	if sampled == sentry.SampledTrue {
+		return fmt.Sprintf("00-%v-%v-01", traceID, spanID)
+	}
+	return fmt.Sprintf("00-%v-%v-00", traceID, spanID)
+}
+
+// parseBaggageHeader returns the valid list members of a W3C baggage header, within the limits set by the spec.
+func parseBaggageHeader(value string) []string {
+	if value == "" || len(value) > maxBaggageBytes {
+		return nil
+	}
+
+	var members []string
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member != "" && baggageMemberRegexp.MatchString(member) {
+			members = append(members, member)
+		}
+		if len(members) == maxBaggageMembers {
+			break
+		}
+	}
+
+	return members
+}
+
+// parseB3SingleHeader parses the "b3" header, in the "{TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}" format.
+// The sampling state and parent span ID are optional, headers only carrying a sampling state are not recognized.
+func parseB3SingleHeader(value string) (sentry.TraceID, sentry.SpanID, sentry.Sampled, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+	}
+
+	sampled := sentry.SampledUndefined
+	if len(parts) > 2 {
+		var ok bool
+		if sampled, ok = parseB3Sampled(parts[2]); !ok {
+			return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+		}
+	}
+
+	if len(parts) > 3 && !b3SpanIDRegexp.MatchString(parts[3]) {
+		return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+	}
+
+	return parseB3IDs(parts[0], parts[1], sampled)
+}
+
+// parseB3MultiHeaders parses the "X-B3-TraceId", "X-B3-SpanId", "X-B3-Sampled", and "X-B3-Flags" headers.
+func parseB3MultiHeaders(c carrier) (sentry.TraceID, sentry.SpanID, sentry.Sampled, bool) {
+	sampled := sentry.SampledUndefined
+
+	if value := strings.TrimSpace(c.get(b3SampledHeader)); value != "" {
+		var ok bool
+		if sampled, ok = parseB3Sampled(value); !ok {
+			return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+		}
+	}
+
+	if strings.TrimSpace(c.get(b3FlagsHeader)) == "1" {
+		sampled = sentry.SampledTrue
+	}
+
+	return parseB3IDs(strings.TrimSpace(c.get(b3TraceIDHeader)), strings.TrimSpace(c.get(b3SpanIDHeader)), sampled)
+}
+
+// parseB3IDs parses B3 trace and span IDs, 64-bit trace IDs are left-padded with zeros to 128 bits.
+func parseB3IDs(traceIDValue, spanIDValue string, sampled sentry.Sampled) (sentry.TraceID, sentry.SpanID, sentry.Sampled, bool) {
+	var traceID sentry.TraceID
+	var spanID sentry.SpanID
+
+	if !b3TraceIDRegexp.MatchString(traceIDValue) || !b3SpanIDRegexp.MatchString(spanIDValue) {
+		return traceID, spanID, sentry.SampledFalse, false
+	}
+
+	_, _ = hex.Decode(traceID[len(traceID)-len(traceIDValue)/2:], []byte(traceIDValue))
+	_, _ = hex.Decode(spanID[:], []byte(spanIDValue))
+
+	if traceID == (sentry.TraceID{}) || spanID == (sentry.SpanID{}) {
+		return sentry.TraceID{}, sentry.SpanID{}, sentry.SampledFalse, false
+	}
+
+	return traceID, spanID, sampled, true
+}
+
+func parseB3Sampled(value string) (sentry.Sampled, bool) {
+	switch value {
+	case "1", "true", "d":
+		return sentry.SampledTrue, true
+	case "0", "false":
+		return sentry.SampledFalse, true
+	default:
+		return sentry.SampledUndefined, false
+	}
+}
+
+func formatB3Sampled(sampled sentry.Sampled) string {
+	switch sampled {
+	case sentry.SampledTrue:
+		return "1"
+	case sentry.SampledFalse:
+		return "0"
+	default:
+		return ""
+	}
+}
+
+func formatB3SingleHeader(traceID sentry.TraceID, spanID sentry.SpanID, sampled sentry.Sampled) string {
+	if s := formatB3Sampled(sampled); s != "" {
+		return fmt.Sprintf("%v-%v-%v", traceID, spanID, s)
+	}
+	return fmt.Sprintf("%v-%v", traceID, spanID)
+}
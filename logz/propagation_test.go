@@ -0,0 +1,253 @@
+package logz
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceParentHeader(t *testing.T) {
+	traceID, parentSpanID, sampled, ok := parseTraceParentHeader("00-0123456789abcdef0123456789abcdef-0123456789abcdef-01")
+	require.True(t, ok)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", traceID.String())
+	require.Equal(t, "0123456789abcdef", parentSpanID.String())
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	traceID, parentSpanID, sampled, ok = parseTraceParentHeader("00-0123456789abcdef0123456789abcdef-0123456789abcdef-00")
+	require.True(t, ok)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", traceID.String())
+	require.Equal(t, "0123456789abcdef", parentSpanID.String())
+	require.Equal(t, sentry.SampledFalse, sampled)
+
+	_, _, sampled, ok = parseTraceParentHeader("01-0123456789abcdef0123456789abcdef-0123456789abcdef-03-future")
+	require.True(t, ok)
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	for _, value := range []string{
+		"",
+		"bad",
+		"00-0123456789abcdef0123456789abcdef-0123456789abcdef-01-extra",
+		"ff-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+		"00-00000000000000000000000000000000-0123456789abcdef-01",
+		"00-0123456789abcdef0123456789abcdef-0000000000000000-01",
+		"00-0123456789ABCDEF0123456789ABCDEF-0123456789abcdef-01",
+	} {
+		traceID, parentSpanID, sampled, ok = parseTraceParentHeader(value)
+		require.False(t, ok, value)
+		require.Equal(t, sentry.TraceID{}, traceID)
+		require.Equal(t, sentry.SpanID{}, parentSpanID)
+		require.Equal(t, sentry.SampledFalse, sampled)
+	}
+}
+
+func TestFormatHeaders(t *testing.T) {
+	traceID, spanID, _, ok := parseSentryTraceHeader("0123456789abcdef0123456789abcdef-0123456789abcdef")
+	require.True(t, ok)
+
+	require.Equal(t, "0123456789abcdef0123456789abcdef-0123456789abcdef-1", formatSentryTraceHeader(traceID, spanID, sentry.SampledTrue))
+	require.Equal(t, "0123456789abcdef0123456789abcdef-0123456789abcdef-0", formatSentryTraceHeader(traceID, spanID, sentry.SampledFalse))
+	require.Equal(t, "0123456789abcdef0123456789abcdef-0123456789abcdef", formatSentryTraceHeader(traceID, spanID, sentry.SampledUndefined))
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01", formatTraceParentHeader(traceID, spanID, sentry.SampledTrue))
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-00", formatTraceParentHeader(traceID, spanID, sentry.SampledFalse))
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-00", formatTraceParentHeader(traceID, spanID, sentry.SampledUndefined))
+}
+
+func TestParseBaggageHeader(t *testing.T) {
+	require.Nil(t, parseBaggageHeader(""))
+	require.Nil(t, parseBaggageHeader(strings.Repeat("k=v,", maxBaggageBytes)))
+	require.Equal(t, []string{"k1=v1", "k2=v2;p=1", "k3="}, parseBaggageHeader(" k1=v1 , bad, k2=v2;p=1,,k3=, k4=\"v4\""))
+	require.Len(t, parseBaggageHeader(strings.Repeat("k=v,", maxBaggageMembers+1)), maxBaggageMembers)
+}
+
+func TestCarriers(t *testing.T) {
+	h := headerCarrier(http.Header{})
+	h.set("traceparent", "v")
+	require.Equal(t, "v", h.get("Traceparent"))
+	require.Equal(t, "v", http.Header(h).Get("traceparent"))
+
+	m := mapCarrier{"Sentry-Trace": "v1"}
+	m.set("traceparent", "v2")
+	require.Equal(t, "v1", m.get("sentry-trace"))
+	require.Equal(t, "v2", m.get("traceparent"))
+	require.Equal(t, "", m.get("baggage"))
+}
+
+func TestExtractInjectTraceContext(t *testing.T) {
+	all := []Propagator{SentryPropagator, W3CPropagator, BaggagePropagator}
+
+	tc := extractTraceContext(all, mapCarrier{
+		"traceparent": "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+		"tracestate":  " vendor=value ",
+		"baggage":     "k=v",
+	})
+	require.True(t, tc.isValid)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", tc.traceID.String())
+	require.Equal(t, "0123456789abcdef", tc.spanID.String())
+	require.Equal(t, sentry.SampledTrue, tc.sampled)
+	require.Equal(t, "vendor=value", tc.traceState)
+	require.Equal(t, []string{"k=v"}, tc.baggage)
+
+	c := mapCarrier{}
+	injectTraceContext(all, c, tc)
+	require.Equal(t, mapCarrier{
+		"sentry-trace": "0123456789abcdef0123456789abcdef-0123456789abcdef-1",
+		"traceparent":  "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+		"tracestate":   "vendor=value",
+		"baggage":      "k=v",
+	}, c)
+
+	tc = extractTraceContext(all, mapCarrier{
+		"sentry-trace": "fedcba9876543210fedcba9876543210-fedcba9876543210-0",
+		"traceparent":  "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+		"tracestate":   "vendor=value",
+	})
+	require.True(t, tc.isValid)
+	require.Equal(t, "fedcba9876543210fedcba9876543210", tc.traceID.String())
+	require.Equal(t, sentry.SampledFalse, tc.sampled)
+	require.Empty(t, tc.traceState)
+
+	tc = extractTraceContext([]Propagator{SentryPropagator}, mapCarrier{
+		"traceparent": "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01",
+	})
+	require.False(t, tc.isValid)
+
+	c = mapCarrier{}
+	injectTraceContext(all, c, tc)
+	require.Empty(t, c)
+
+	fixturez.RequirePanicsWith(t, "unknown propagator: unknown", func() {
+		Propagator("unknown").extract(mapCarrier{}, &traceContext{})
+	})
+
+	fixturez.RequirePanicsWith(t, "unknown propagator: unknown", func() {
+		Propagator("unknown").inject(mapCarrier{}, &traceContext{})
+	})
+}
+
+func TestTraceContextToContext(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, ctx, (&traceContext{}).toContext(ctx))
+
+	ctx = (&traceContext{traceState: "vendor=value", baggage: []string{"k=v"}}).toContext(ctx)
+	span := &sentry.Span{Sampled: sentry.SampledTrue}
+	tc := newSpanTraceContext(ctx, span)
+	require.True(t, tc.isValid)
+	require.Equal(t, "vendor=value", tc.traceState)
+	require.Equal(t, []string{"k=v"}, tc.baggage)
+
+	require.Equal(t, defaultPropagators, getPropagators(ctx))
+	require.Equal(t, []Propagator{W3CPropagator}, getPropagators(
+		context.WithValue(ctx, logsContextKey, &logsImpl{propagators: []Propagator{W3CPropagator}})))
+}
+
+func TestParseB3Headers(t *testing.T) {
+	traceID, spanID, sampled, ok := parseB3SingleHeader("0123456789abcdef0123456789abcdef-0123456789abcdef-1-fedcba9876543210")
+	require.True(t, ok)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", traceID.String())
+	require.Equal(t, "0123456789abcdef", spanID.String())
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	traceID, spanID, sampled, ok = parseB3SingleHeader("0123456789abcdef-fedcba9876543210")
+	require.True(t, ok)
+	require.Equal(t, "00000000000000000123456789abcdef", traceID.String())
+	require.Equal(t, "fedcba9876543210", spanID.String())
+	require.Equal(t, sentry.SampledUndefined, sampled)
+
+	_, _, sampled, ok = parseB3SingleHeader("0123456789abcdef-fedcba9876543210-d")
+	require.True(t, ok)
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	_, _, sampled, ok = parseB3SingleHeader("0123456789abcdef-fedcba9876543210-0")
+	require.True(t, ok)
+	require.Equal(t, sentry.SampledFalse, sampled)
+
+	for _, value := range []string{
+		"",
+		"0",
+		"1",
+		"0123456789abcdef-fedcba9876543210-x",
+		"0123456789abcdef-fedcba9876543210-1-bad",
+		"0123456789abcdef-fedcba9876543210-1-fedcba9876543210-extra",
+		"0123456789abcdef01-fedcba9876543210",
+		"0000000000000000-fedcba9876543210",
+		"0123456789abcdef-0000000000000000",
+		"0123456789ABCDEF-fedcba9876543210",
+	} {
+		traceID, spanID, sampled, ok = parseB3SingleHeader(value)
+		require.False(t, ok, value)
+		require.Equal(t, sentry.TraceID{}, traceID)
+		require.Equal(t, sentry.SpanID{}, spanID)
+		require.Equal(t, sentry.SampledFalse, sampled)
+	}
+
+	traceID, spanID, sampled, ok = parseB3MultiHeaders(headerCarrier(http.Header{
+		"X-B3-Traceid": []string{"0123456789abcdef"},
+		"X-B3-Spanid":  []string{"fedcba9876543210"},
+		"X-B3-Sampled": []string{"true"},
+	}))
+	require.True(t, ok)
+	require.Equal(t, "00000000000000000123456789abcdef", traceID.String())
+	require.Equal(t, "fedcba9876543210", spanID.String())
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	_, _, sampled, ok = parseB3MultiHeaders(mapCarrier{
+		"x-b3-traceid": "0123456789abcdef0123456789abcdef",
+		"x-b3-spanid":  "fedcba9876543210",
+		"x-b3-flags":   "1",
+	})
+	require.True(t, ok)
+	require.Equal(t, sentry.SampledTrue, sampled)
+
+	_, _, _, ok = parseB3MultiHeaders(mapCarrier{
+		"x-b3-traceid": "0123456789abcdef0123456789abcdef",
+		"x-b3-spanid":  "fedcba9876543210",
+		"x-b3-sampled": "bad",
+	})
+	require.False(t, ok)
+
+	_, _, _, ok = parseB3MultiHeaders(mapCarrier{})
+	require.False(t, ok)
+}
+
+func TestExtractInjectB3(t *testing.T) {
+	tc := extractTraceContext([]Propagator{B3MultiPropagator}, mapCarrier{
+		"b3": "0123456789abcdef-fedcba9876543210-1",
+	})
+	require.True(t, tc.isValid)
+	require.Equal(t, "00000000000000000123456789abcdef", tc.traceID.String())
+
+	tc = extractTraceContext([]Propagator{SentryPropagator, B3Propagator}, mapCarrier{
+		"X-B3-TraceId": "0123456789abcdef0123456789abcdef",
+		"X-B3-SpanId":  "fedcba9876543210",
+	})
+	require.True(t, tc.isValid)
+	require.Equal(t, "0123456789abcdef0123456789abcdef", tc.traceID.String())
+	require.Equal(t, sentry.SampledUndefined, tc.sampled)
+
+	c := mapCarrier{}
+	injectTraceContext([]Propagator{B3Propagator, B3MultiPropagator}, c, tc)
+	require.Equal(t, mapCarrier{
+		"b3":           "0123456789abcdef0123456789abcdef-fedcba9876543210",
+		"X-B3-TraceId": "0123456789abcdef0123456789abcdef",
+		"X-B3-SpanId":  "fedcba9876543210",
+	}, c)
+
+	tc.sampled = sentry.SampledFalse
+	c = mapCarrier{}
+	injectTraceContext([]Propagator{B3Propagator, B3MultiPropagator}, c, tc)
+	require.Equal(t, mapCarrier{
+		"b3":           "0123456789abcdef0123456789abcdef-fedcba9876543210-0",
+		"X-B3-TraceId": "0123456789abcdef0123456789abcdef",
+		"X-B3-SpanId":  "fedcba9876543210",
+		"X-B3-Sampled": "0",
+	}, c)
+
+	c = mapCarrier{}
+	injectTraceContext([]Propagator{B3Propagator, B3MultiPropagator}, c, &traceContext{})
+	require.Empty(t, c)
+}
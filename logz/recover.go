@@ -0,0 +1,68 @@
+package logz
+
+import (
+	"context"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	mechanismTagKey = "mechanism"
+	panicMechanism  = "panic"
+)
+
+// RecoverOption describes an option for Recover and Go.
+type RecoverOption func(c *recoverConfig)
+
+type recoverConfig struct {
+	rePanic bool
+}
+
+// RePanic re-panics with the recovered value once it has been reported and flushed.
+func RePanic() RecoverOption {
+	return func(c *recoverConfig) {
+		c.rePanic = true
+	}
+}
+
+// Recover recovers from panics, reporting them as errors and flushing. It must be called directly using defer.
+func Recover(ctx context.Context, options ...RecoverOption) {
+	if r := recover(); r != nil {
+		handlePanic(ctx, r, options...)
+	}
+}
+
+// Go runs fn in a new goroutine with a forked context (see Fork), recovering from panics as Recover does.
+func Go(ctx context.Context, fn func(ctx context.Context), options ...RecoverOption) {
+	ctx = Fork(ctx)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				handlePanic(ctx, r, options...)
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+func handlePanic(ctx context.Context, r interface{}, options ...RecoverOption) {
+	cfg := &recoverConfig{}
+	for _, o := range options {
+		o(cfg)
+	}
+
+	logs := Get(ctx)
+	logs.Error(wrapPanic(r))
+	logs.Flush()
+
+	if cfg.rePanic {
+		panic(r)
+	}
+}
+
+// wrapPanic converts a recovered value to an error with the panicking goroutine stack, marked with the panic mechanism.
+func wrapPanic(r interface{}) error {
+	return errorz.Wrap(errorz.WrapRecover(r, errorz.SkipPackage()), ErrorTag(mechanismTagKey, panicMechanism))
+}
@@ -0,0 +1,153 @@
+package logz_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type RecoverSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestRecover(t *testing.T) {
+	fixturez.RunSuite(t, &RecoverSuite{})
+}
+
+type flushSignalTransport struct {
+	*testTransport
+	flushed chan struct{}
+}
+
+// Flush implements the sentry.Transport interface.
+func (t *flushSignalTransport) Flush(timeout time.Duration) bool {
+	defer func() {
+		t.flushed <- struct{}{}
+	}()
+	return t.testTransport.Flush(timeout)
+}
+
+func setupRecoverLogs(ctx context.Context) (context.Context, func(), *testTransport, <-chan *sentry.Event, <-chan struct{}) {
+	events := make(chan *sentry.Event, 10)
+	flushed := make(chan struct{}, 10)
+
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.SentryTransport = &flushSignalTransport{
+			testTransport: cfg.SentryTransport.(*testTransport),
+			flushed:       flushed,
+		}
+		cfg.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			events <- event
+			return event
+		}
+	})
+
+	return ctx, releaser, transport, events, flushed
+}
+
+func requirePanicEvent(t *testing.T, event *sentry.Event, value, function string) {
+	require.Equal(t, sentry.LevelError, event.Level)
+	require.Equal(t, value, event.Exception[0].Value)
+	require.Equal(t, "panic", event.Tags["mechanism"])
+
+	frames := event.Exception[0].Stacktrace.Frames
+	found := false
+	for _, frame := range frames {
+		if strings.HasPrefix(frame.Function, function) {
+			found = true
+		}
+		require.NotEqual(t, "github.com/ibrt/golang-inject-logs/logz", frame.Module)
+	}
+	require.True(t, found, "missing frame: %v", function)
+}
+
+func (s *RecoverSuite) TestRecover(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, transport, events, _ := setupRecoverLogs(ctx)
+	defer releaser()
+
+	func() {
+		defer logz.Recover(ctx)
+	}()
+	require.Empty(t, events)
+
+	func() {
+		defer logz.Recover(ctx)
+		panic("test panic")
+	}()
+
+	require.Len(t, events, 1)
+	requirePanicEvent(t, <-events, "test panic", "(*RecoverSuite).TestRecover")
+	require.True(t, transport.isFlushed)
+
+	func() {
+		defer logz.Recover(ctx)
+		panic(errorz.Errorf("test error", errorz.M("k", "v")))
+	}()
+
+	require.Len(t, events, 1)
+	event := <-events
+	requirePanicEvent(t, event, "test error", "(*RecoverSuite).TestRecover")
+	require.Equal(t, "v", event.Extra["k"])
+
+	require.Contains(t, c.GetErrString(), `"mechanism":"panic","msg":"test panic"`)
+}
+
+func (s *RecoverSuite) TestRecoverRePanic(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, _, events, _ := setupRecoverLogs(ctx)
+	defer releaser()
+
+	fixturez.RequirePanicsWith(t, "test panic", func() {
+		defer logz.Recover(ctx, logz.RePanic())
+		panic("test panic")
+	})
+
+	require.Len(t, events, 1)
+	requirePanicEvent(t, <-events, "test panic", "(*RecoverSuite).TestRecoverRePanic")
+}
+
+func (s *RecoverSuite) TestGo(ctx context.Context, t *testing.T) {
+	c := fixturez.CaptureOutput()
+	defer c.Close()
+	ctx, releaser, _, events, flushed := setupRecoverLogs(ctx)
+	defer releaser()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	logz.Go(ctx, func(goCtx context.Context) {
+		defer wg.Done()
+		require.NotSame(t, sentry.GetHubFromContext(ctx), sentry.GetHubFromContext(goCtx))
+		require.Equal(t, logz.Get(ctx).GetLevels(), logz.Get(goCtx).GetLevels())
+	})
+	wg.Wait()
+
+	logz.Go(ctx, func(_ context.Context) {
+		panic("test panic")
+	})
+
+	requirePanicEvent(t, <-events, "test panic", "(*RecoverSuite).TestGo")
+	<-flushed
+}
+
+func (s *RecoverSuite) TestRecoverNoop(_ context.Context, t *testing.T) {
+	func() {
+		defer logz.Recover(context.Background())
+		panic("test panic")
+	}()
+
+	require.True(t, logz.Get(context.Background()).Flush())
+}
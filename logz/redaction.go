@@ -0,0 +1,400 @@
+package logz
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-validation/vz"
+)
+
+const (
+	redactedValue         = "[Filtered]"
+	redactionPathPrefix   = "$."
+	redactionPathWildcard = "*"
+	cookieHeader          = "cookie"
+)
+
+var (
+	creditCardRegexp = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	jwtRegexp        = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	emailRegexp      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// Scrubber describes a built-in scrubber, which redacts matching substrings of values.
+type Scrubber string
+
+// Known scrubbers.
+const (
+	CreditCardScrubber Scrubber = "creditCard"
+	JWTScrubber        Scrubber = "jwt"
+	EmailScrubber      Scrubber = "email"
+)
+
+func (s Scrubber) toScrubber() *scrubber {
+	switch s {
+	case CreditCardScrubber:
+		return &scrubber{re: creditCardRegexp, isMatch: isLuhnValid}
+	case JWTScrubber:
+		return &scrubber{re: jwtRegexp}
+	case EmailScrubber:
+		return &scrubber{re: emailRegexp}
+	default:
+		panic(errorz.Errorf("unknown scrubber: %v", errorz.A(s), errorz.SkipPackage()))
+	}
+}
+
+// scrubber redacts the substrings matching re, which are also accepted by isMatch if not nil.
+type scrubber struct {
+	re      *regexp.Regexp
+	isMatch func(s string) bool
+}
+
+func (s *scrubber) scrub(v string) string {
+	if s.isMatch == nil {
+		return s.re.ReplaceAllLiteralString(v, redactedValue)
+	}
+
+	return s.re.ReplaceAllStringFunc(v, func(m string) string {
+		if s.isMatch(m) {
+			return redactedValue
+		}
+		return m
+	})
+}
+
+// isLuhnValid returns true if the digits in s (ignoring other characters) pass the Luhn checksum.
+func isLuhnValid(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+
+		d := int(s[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// RedactionConfig describes how sensitive data is redacted from events, before they are output or sent to Sentry:
+//   - DenyKeys are matched case-insensitively against metadata, tags, headers, query params and JSON body keys;
+//   - Scrubbers and ScrubPatterns (regular expressions) redact matching substrings of all other values;
+//   - Headers and QueryParams are matched case-insensitively against the request headers and query params;
+//   - BodyPaths are dot-separated paths into JSON request bodies (e.g. "user.password", "cards.*.number").
+type RedactionConfig struct {
+	DenyKeys      []string   `json:"denyKeys" validate:"dive,required"`
+	Scrubbers     []Scrubber `json:"scrubbers" validate:"dive,oneof=creditCard jwt email"`
+	ScrubPatterns []string   `json:"scrubPatterns" validate:"dive,required"`
+	Headers       []string   `json:"headers" validate:"dive,required"`
+	QueryParams   []string   `json:"queryParams" validate:"dive,required"`
+	BodyPaths     []string   `json:"bodyPaths" validate:"dive,required"`
+}
+
+// NewDefaultRedactionConfig returns a RedactionConfig which covers common credentials and PII.
+func NewDefaultRedactionConfig() *RedactionConfig {
+	return &RedactionConfig{
+		DenyKeys:    []string{"password", "passwd", "secret", "token", "accessToken", "access_token", "refreshToken", "refresh_token", "apiKey", "api_key", "authorization", "cookie"},
+		Scrubbers:   []Scrubber{CreditCardScrubber, JWTScrubber, EmailScrubber},
+		Headers:     []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+		QueryParams: []string{"token", "access_token", "api_key", "apiKey"},
+	}
+}
+
+// Validate implements the vz.Validator interface.
+func (c *RedactionConfig) Validate() error {
+	if err := vz.ValidateStruct(c); err != nil {
+		return errorz.Wrap(err, errorz.Skip())
+	}
+
+	for _, p := range c.ScrubPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return errorz.Wrap(err, errorz.Prefix("invalid scrub pattern"), errorz.Skip())
+		}
+	}
+
+	return nil
+}
+
+// redactor redacts events according to a RedactionConfig, its methods are safe to call on a nil redactor.
+type redactor struct {
+	denyKeys    map[string]struct{}
+	scrubbers   []*scrubber
+	headers     map[string]struct{}
+	queryParams map[string]struct{}
+	bodyPaths   [][]string
+}
+
+func newRedactor(cfg *RedactionConfig) *redactor {
+	if cfg == nil {
+		return nil
+	}
+
+	r := &redactor{
+		denyKeys:    newRedactionKeySet(cfg.DenyKeys),
+		headers:     newRedactionKeySet(cfg.Headers),
+		queryParams: newRedactionKeySet(cfg.QueryParams),
+	}
+
+	for _, s := range cfg.Scrubbers {
+		r.scrubbers = append(r.scrubbers, s.toScrubber())
+	}
+
+	for _, p := range cfg.ScrubPatterns {
+		r.scrubbers = append(r.scrubbers, &scrubber{re: regexp.MustCompile(p)})
+	}
+
+	for _, p := range cfg.BodyPaths {
+		r.bodyPaths = append(r.bodyPaths, strings.Split(strings.TrimPrefix(p, redactionPathPrefix), "."))
+	}
+
+	return r
+}
+
+func newRedactionKeySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return set
+}
+
+func isRedactionKey(set map[string]struct{}, k string) bool {
+	_, ok := set[strings.ToLower(k)]
+	return ok
+}
+
+// redactEvent redacts the event in place, replacing rather than modifying any value which may be shared.
+func (r *redactor) redactEvent(event *sentry.Event) {
+	if r == nil || event == nil {
+		return
+	}
+
+	event.Message = r.scrub(event.Message)
+	event.Extra = r.redactMap(event.Extra)
+	event.Tags = r.redactStringMap(event.Tags, nil)
+
+	for i := range event.Exception {
+		event.Exception[i].Value = r.scrub(event.Exception[i].Value)
+	}
+
+	event.User.Email = r.scrub(event.User.Email)
+	event.User.Username = r.scrub(event.User.Username)
+
+	if event.Request != nil {
+		event.Request = r.redactRequest(event.Request)
+	}
+
+	for i, b := range event.Breadcrumbs {
+		rb := *b
+		rb.Message = r.scrub(b.Message)
+		rb.Data = r.redactMap(b.Data)
+		event.Breadcrumbs[i] = &rb
+	}
+
+	if event.Spans != nil {
+		spans := make([]*sentry.Span, len(event.Spans))
+
+		for i, s := range event.Spans {
+			rs := *s
+			rs.Description = r.scrub(s.Description)
+			rs.Data = r.redactMap(s.Data)
+			rs.Tags = r.redactStringMap(s.Tags, nil)
+			spans[i] = &rs
+		}
+
+		event.Spans = spans
+	}
+}
+
+func (r *redactor) redactRequest(req *sentry.Request) *sentry.Request {
+	rReq := *req
+	rReq.URL = r.scrub(req.URL)
+	rReq.Headers = r.redactStringMap(req.Headers, r.headers)
+	rReq.QueryString = r.redactQueryString(req.QueryString)
+	rReq.Data = r.redactBody(req.Data)
+
+	if isRedactionKey(r.headers, cookieHeader) || isRedactionKey(r.denyKeys, cookieHeader) {
+		rReq.Cookies = redactEmpty(req.Cookies)
+	} else {
+		rReq.Cookies = r.scrub(req.Cookies)
+	}
+
+	return &rReq
+}
+
+func (r *redactor) redactQueryString(qs string) string {
+	if qs == "" {
+		return qs
+	}
+
+	params := strings.Split(qs, "&")
+
+	for i, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+
+		k, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			k = kv[0]
+		}
+
+		if len(kv) == 2 && (isRedactionKey(r.queryParams, k) || isRedactionKey(r.denyKeys, k)) {
+			params[i] = kv[0] + "=" + redactedValue
+			continue
+		}
+
+		params[i] = r.scrub(param)
+	}
+
+	return strings.Join(params, "&")
+}
+
+func (r *redactor) redactBody(body string) string {
+	if body == "" {
+		return body
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return r.scrub(body)
+	}
+
+	for _, path := range r.bodyPaths {
+		v = redactPath(v, path)
+	}
+
+	buf, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return redactedValue
+	}
+
+	return string(buf)
+}
+
+// redactPath redacts the values matching the path in place, the value must be freshly unmarshaled JSON.
+func redactPath(v interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return redactedValue
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, c := range v {
+			if path[0] == redactionPathWildcard || path[0] == k {
+				v[k] = redactPath(c, path[1:])
+			}
+		}
+	case []interface{}:
+		for i, c := range v {
+			if path[0] == redactionPathWildcard || path[0] == strconv.Itoa(i) {
+				v[i] = redactPath(c, path[1:])
+			}
+		}
+	}
+
+	return v
+}
+
+func (r *redactor) redactMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	rm := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		if isRedactionKey(r.denyKeys, k) {
+			rm[k] = redactedValue
+			continue
+		}
+		rm[k] = r.redactValue(v)
+	}
+
+	return rm
+}
+
+func (r *redactor) redactStringMap(m map[string]string, keys map[string]struct{}) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	rm := make(map[string]string, len(m))
+
+	for k, v := range m {
+		if isRedactionKey(keys, k) || isRedactionKey(r.denyKeys, k) {
+			rm[k] = redactedValue
+			continue
+		}
+		rm[k] = r.scrub(v)
+	}
+
+	return rm
+}
+
+func (r *redactor) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return r.scrub(v)
+	case json.Number:
+		if s := v.String(); r.scrub(s) != s {
+			return redactedValue
+		}
+		return v
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case Metadata:
+		return Metadata(r.redactMap(v))
+	case map[string]string:
+		return r.redactStringMap(v, nil)
+	case []interface{}:
+		rv := make([]interface{}, len(v))
+		for i, e := range v {
+			rv[i] = r.redactValue(e)
+		}
+		return rv
+	case []string:
+		rv := make([]string, len(v))
+		for i, e := range v {
+			rv[i] = r.scrub(e)
+		}
+		return rv
+	case error:
+		if s := v.Error(); r.scrub(s) != s {
+			return r.scrub(s)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (r *redactor) scrub(s string) string {
+	for _, sc := range r.scrubbers {
+		s = sc.scrub(s)
+	}
+	return s
+}
+
+func redactEmpty(s string) string {
+	if s == "" {
+		return s
+	}
+	return redactedValue
+}
@@ -0,0 +1,74 @@
+package logz
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getsentry/sentry-go"
+)
+
+const (
+	httpClientOp   = "http.client"
+	httpMethodKey  = "http.method"
+	httpURLKey     = "http.url"
+	httpErrorKey   = "error"
+	defaultURLDesc = "<unknown>"
+)
+
+var (
+	_ http.RoundTripper = &roundTripper{}
+)
+
+type roundTripper struct {
+	base http.RoundTripper
+}
+
+// NewRoundTripper returns a http.RoundTripper which traces outgoing requests as "http.client" spans and propagates the
+// trace downstream using the configured propagators. If base is nil, http.DefaultTransport is used.
+func NewRoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &roundTripper{
+		base: base,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := defaultURLDesc
+	if req.URL != nil {
+		u := *req.URL
+		u.User = nil
+		u.RawQuery = ""
+		u.ForceQuery = false
+		u.Fragment = ""
+		url = u.String()
+	}
+
+	ctx, finishSpan := Get(req.Context()).TraceSpan(httpClientOp, fmt.Sprintf("%v %v", req.Method, url))
+	defer finishSpan()
+
+	span, ok := ctx.Value(logsSpanContextKey).(*sentry.Span)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	span.Data[httpMethodKey] = req.Method
+	span.Data[httpURLKey] = url
+
+	req = req.Clone(ctx)
+	injectTraceContext(getPropagators(ctx), headerCarrier(req.Header), newSpanTraceContext(ctx, span))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.Data[httpErrorKey] = err.Error()
+		span.Status = errorToSpanStatus(err)
+		return resp, err
+	}
+
+	span.Data[httpStatusCodeKey] = resp.StatusCode
+	span.Status = httpStatusToSpanStatus(resp.StatusCode)
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package logz_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type RoundTripperSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestRoundTripper(t *testing.T) {
+	fixturez.RunSuite(t, &RoundTripperSuite{})
+}
+
+func (s *RoundTripperSuite) TestRoundTripper(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	var sentryTrace string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentryTrace = r.Header.Get("sentry-trace")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	func() {
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(httptest.NewRequest("GET", "/path", nil), nil)
+		defer releaseTransaction()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/outgoing?secret=value", nil)
+		fixturez.RequireNoError(t, err)
+		resp, err := logz.NewRoundTripper(nil).RoundTrip(req)
+		fixturez.RequireNoError(t, err)
+		defer errorz.IgnoreClose(resp.Body)
+		require.Equal(t, http.StatusTeapot, resp.StatusCode)
+		require.Empty(t, req.Header.Get("sentry-trace"))
+	}()
+
+	require.Len(t, transport.events, 1)
+	require.Len(t, transport.events[0].Spans, 1)
+	span := transport.events[0].Spans[0]
+	require.Equal(t, "http.client", span.Op)
+	require.Equal(t, "GET "+srv.URL+"/outgoing", span.Description)
+	require.Equal(t, sentry.SpanStatusInvalidArgument, span.Status)
+	require.Equal(t, map[string]interface{}{
+		"http.method":      "GET",
+		"http.url":         srv.URL + "/outgoing",
+		"http.status_code": http.StatusTeapot,
+	}, span.Data)
+	require.Equal(t, span.ToSentryTrace(), sentryTrace)
+}
+
+func (s *RoundTripperSuite) TestRoundTripperError(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	func() {
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(httptest.NewRequest("GET", "/path", nil), nil)
+		defer releaseTransaction()
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "http://example.com/outgoing", nil)
+		fixturez.RequireNoError(t, err)
+		_, err = logz.NewRoundTripper(roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+			return nil, context.DeadlineExceeded
+		})).RoundTrip(req)
+		require.Equal(t, context.DeadlineExceeded, err)
+	}()
+
+	require.Len(t, transport.events, 1)
+	require.Len(t, transport.events[0].Spans, 1)
+	span := transport.events[0].Spans[0]
+	require.Equal(t, sentry.SpanStatusDeadlineExceeded, span.Status)
+	require.Equal(t, map[string]interface{}{
+		"http.method": "POST",
+		"http.url":    "http://example.com/outgoing",
+		"error":       context.DeadlineExceeded.Error(),
+	}, span.Data)
+}
+
+func (s *RoundTripperSuite) TestRoundTripperNoop(_ context.Context, t *testing.T) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
+	fixturez.RequireNoError(t, err)
+
+	_, err = logz.NewRoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		require.Empty(t, r.Header.Get("sentry-trace"))
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})).RoundTrip(req)
+	fixturez.RequireNoError(t, err)
+}
+
+func (s *RoundTripperSuite) TestRoundTripperPropagators(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.Propagators = []logz.Propagator{logz.W3CPropagator, logz.BaggagePropagator}
+	})
+	defer releaser()
+
+	var header http.Header
+	rt := logz.NewRoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		header = r.Header
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	func() {
+		inReq := httptest.NewRequest("GET", "/path", nil)
+		inReq.Header.Set("traceparent", "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01")
+		inReq.Header.Set("tracestate", "vendor=value")
+		inReq.Header.Set("baggage", "k=v")
+
+		ctx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(inReq, nil)
+		defer releaseTransaction()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://example.com/outgoing", nil)
+		fixturez.RequireNoError(t, err)
+		_, err = rt.RoundTrip(req)
+		fixturez.RequireNoError(t, err)
+	}()
+
+	require.Len(t, transport.events, 1)
+	require.Len(t, transport.events[0].Spans, 1)
+	span := transport.events[0].Spans[0]
+	require.Equal(t, "0123456789abcdef0123456789abcdef", span.TraceID.String())
+	require.Equal(t, "0123456789abcdef", transport.events[0].Contexts["trace"].(*sentry.TraceContext).ParentSpanID.String())
+
+	require.Empty(t, header.Get("sentry-trace"))
+	require.Equal(t, "00-0123456789abcdef0123456789abcdef-"+span.SpanID.String()+"-01", header.Get("traceparent"))
+	require.Equal(t, "vendor=value", header.Get("tracestate"))
+	require.Equal(t, "k=v", header.Get("baggage"))
+}
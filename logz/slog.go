@@ -0,0 +1,182 @@
+package logz
+
+import (
+	"context"
+	"log/slog"
+)
+
+const (
+	slogGroupSeparator = "."
+)
+
+var (
+	_ slog.Handler = &slogHandler{}
+)
+
+type slogHandler struct {
+	ctx      context.Context
+	prefix   string
+	metadata Metadata
+}
+
+// NewSlogHandler returns a slog.Handler which routes records into the Logs. Records are handled using the Logs, hub and
+// span from their context if available, or from the given context otherwise. Attributes and groups are converted to
+// metadata, with group names joined by ".". At warning and error levels, the first error-valued attribute is reported
+// as an error, keeping its stack trace.
+func NewSlogHandler(ctx context.Context) slog.Handler {
+	return &slogHandler{
+		ctx:      ctx,
+		metadata: Metadata{},
+	}
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return Get(h.getContext(ctx)).Enabled(levelFromSlog(level))
+}
+
+// Handle implements the slog.Handler interface.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	ctx = h.getContext(ctx)
+
+	metadata := make(Metadata, len(h.metadata)+r.NumAttrs())
+	for k, v := range h.metadata {
+		metadata[k] = v
+	}
+
+	var err error
+	var errKey string
+
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(metadata, h.prefix, a, func(k string, v error) {
+			if err == nil {
+				err = v
+				errKey = k
+			}
+		})
+		return true
+	})
+
+	level := levelFromSlog(r.Level)
+	logs := Get(ctx)
+
+	if err != nil && (level == Warning || level == Error) {
+		delete(metadata, errKey)
+		metadata[slog.MessageKey] = r.Message
+		logs = logs.With(metadata)
+
+		if level == Warning {
+			logs.Warning(err)
+		} else {
+			logs.Error(err)
+		}
+
+		return nil
+	}
+
+	options := []Option{A(r.Message), metadata, withLevel(level)}
+	if r.PC != 0 {
+		options = append(options, withCallers(r.PC))
+	}
+
+	if level == Debug {
+		logs.Debug("%s", options...)
+	} else {
+		logs.Info("%s", options...)
+	}
+
+	return nil
+}
+
+// getContext returns the record context if it has Logs, or the handler context otherwise.
+func (h *slogHandler) getContext(ctx context.Context) context.Context {
+	if ctx == nil || ctx.Value(logsContextKey) == nil {
+		return h.ctx
+	}
+	return ctx
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	metadata := make(Metadata, len(h.metadata)+len(attrs))
+	for k, v := range h.metadata {
+		metadata[k] = v
+	}
+
+	for _, a := range attrs {
+		addSlogAttr(metadata, h.prefix, a, nil)
+	}
+
+	return &slogHandler{
+		ctx:      h.ctx,
+		prefix:   h.prefix,
+		metadata: metadata,
+	}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{
+		ctx:      h.ctx,
+		prefix:   h.prefix + name + slogGroupSeparator,
+		metadata: h.metadata,
+	}
+}
+
+// addSlogAttr flattens the given attr into the metadata, calling onError (if not nil) for error values.
+func addSlogAttr(metadata Metadata, prefix string, a slog.Attr, onError func(k string, err error)) {
+	a.Value = a.Value.Resolve()
+
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + slogGroupSeparator
+		}
+		for _, ga := range a.Value.Group() {
+			addSlogAttr(metadata, prefix, ga, onError)
+		}
+		return
+	}
+
+	k := prefix + a.Key
+	v := a.Value.Any()
+	metadata[k] = v
+
+	if err, ok := v.(error); ok && onError != nil {
+		onError(k, err)
+	}
+}
+
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return Trace
+	case level < slog.LevelInfo:
+		return Debug
+	case level < slog.LevelWarn:
+		return Info
+	case level < slog.LevelError:
+		return Warning
+	default:
+		return Error
+	}
+}
+
+// withLevel overrides the level of an entry.
+func withLevel(level Level) Option {
+	return levelOption(level)
+}
+
+// withCallers overrides the callers of an entry.
+func withCallers(callers ...uintptr) OptionFunc {
+	return func(e *entry) {
+		e.callers = callers
+	}
+}
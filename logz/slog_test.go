@@ -0,0 +1,143 @@
+package logz_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-fixtures/fixturez"
+	"github.com/ibrt/golang-inject-clock/clockz/testclockz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrt/golang-inject-logs/logz"
+)
+
+type SlogSuite struct {
+	*fixturez.DefaultConfigMixin
+	Clock *testclockz.MockHelper
+}
+
+func TestSlog(t *testing.T) {
+	fixturez.RunSuite(t, &SlogSuite{})
+}
+
+func (s *SlogSuite) TestSlogHandler(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	logger := slog.New(logz.NewSlogHandler(ctx))
+
+	logger.Debug("debug message", "k", "v")
+	logger.Info("info message", slog.Group("req", slog.String("method", "GET"), slog.Int("status", 200)))
+	logger.With("a", 1).WithGroup("g").With("b", 2).WithGroup("").Warn("warn message", "c", 3, slog.Group("", "d", 4))
+	logger.Log(ctx, slog.LevelError+4, "error message")
+
+	require.Len(t, transport.events, 4)
+
+	require.Equal(t, sentry.LevelDebug, transport.events[0].Level)
+	require.Equal(t, "debug message", transport.events[0].Message)
+	require.Equal(t, map[string]interface{}{"k": "v"}, transport.events[0].Extra)
+	frames := transport.events[0].Threads[0].Stacktrace.Frames
+	require.Len(t, frames, 1)
+	require.Equal(t, "(*SlogSuite).TestSlogHandler", frames[0].Function)
+
+	require.Equal(t, sentry.LevelInfo, transport.events[1].Level)
+	require.Equal(t, map[string]interface{}{"req.method": "GET", "req.status": int64(200)}, transport.events[1].Extra)
+
+	require.Equal(t, sentry.LevelWarning, transport.events[2].Level)
+	require.Equal(t, "warn message", transport.events[2].Message)
+	require.Equal(t, map[string]interface{}{"a": int64(1), "g.b": int64(2), "g.c": int64(3), "g.d": int64(4)}, transport.events[2].Extra)
+
+	require.Equal(t, sentry.LevelError, transport.events[3].Level)
+	require.Equal(t, "error message", transport.events[3].Message)
+	require.Empty(t, transport.events[3].Exception)
+}
+
+func (s *SlogSuite) TestSlogHandlerEnabled(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx, func(cfg *logz.Config) {
+		cfg.OutputLevel = logz.Warning
+		cfg.SentryLevel = logz.Error
+	})
+	defer releaser()
+
+	logger := slog.New(logz.NewSlogHandler(ctx))
+	require.False(t, logger.Enabled(ctx, slog.LevelDebug-4))
+	require.False(t, logger.Enabled(ctx, slog.LevelInfo))
+	require.True(t, logger.Enabled(ctx, slog.LevelWarn))
+	require.True(t, logger.Enabled(context.Background(), slog.LevelWarn))
+
+	logger.Info("info message")
+	require.Empty(t, transport.events)
+
+	logger.Error("error message")
+	require.Len(t, transport.events, 1)
+}
+
+func (s *SlogSuite) TestSlogHandlerError(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	logger := slog.New(logz.NewSlogHandler(ctx))
+	err := errorz.Errorf("test error", errorz.M("ek", "ev"))
+	_, file, line, _ := runtime.Caller(0)
+
+	logger.Error("error message", "err", err, "k", "v")
+	logger.Warn("warn message", slog.Group("g", "err", err))
+	logger.Info("info message", "err", err)
+
+	require.Len(t, transport.events, 3)
+
+	require.Equal(t, sentry.LevelError, transport.events[0].Level)
+	require.Equal(t, "test error", transport.events[0].Exception[0].Value)
+	frames := transport.events[0].Exception[0].Stacktrace.Frames
+	require.Equal(t, file, frames[len(frames)-1].AbsPath)
+	require.Equal(t, line-1, frames[len(frames)-1].Lineno)
+	require.Equal(t, "error message", transport.events[0].Extra["msg"])
+	require.Equal(t, "v", transport.events[0].Extra["k"])
+	require.Equal(t, "ev", transport.events[0].Extra["ek"])
+	require.NotContains(t, transport.events[0].Extra, "err")
+
+	require.Equal(t, sentry.LevelWarning, transport.events[1].Level)
+	require.Equal(t, "test error", transport.events[1].Exception[0].Value)
+	require.NotContains(t, transport.events[1].Extra, "g.err")
+
+	require.Equal(t, sentry.LevelInfo, transport.events[2].Level)
+	require.Empty(t, transport.events[2].Exception)
+	require.Equal(t, err, transport.events[2].Extra["err"])
+}
+
+func (s *SlogSuite) TestSlogHandlerContext(ctx context.Context, t *testing.T) {
+	ctx, releaser, transport := setupLogs(ctx)
+	defer releaser()
+
+	logger := slog.New(logz.NewSlogHandler(ctx))
+
+	func() {
+		reqCtx, releaseTransaction := logz.Get(ctx).TraceHTTPRequestServer(httptest.NewRequest("GET", "/path", nil), nil)
+		defer releaseTransaction()
+		logz.Get(reqCtx).SetUser(&logz.User{ID: "uid"})
+
+		logger.InfoContext(reqCtx, "request message")
+		logger.InfoContext(context.Background(), "background message")
+	}()
+
+	require.Len(t, transport.events, 3)
+	require.Equal(t, "request message", transport.events[0].Message)
+	require.Equal(t, "uid", transport.events[0].User.ID)
+	require.Contains(t, transport.events[0].Request.URL, "/path")
+	require.Equal(t, "background message", transport.events[1].Message)
+	require.Empty(t, transport.events[1].User.ID)
+	require.Nil(t, transport.events[1].Request)
+	require.Equal(t, "transaction", transport.events[2].Type)
+}
+
+func (s *SlogSuite) TestSlogHandlerNoop(_ context.Context, t *testing.T) {
+	logger := slog.New(logz.NewSlogHandler(context.Background()))
+	require.False(t, logger.Enabled(context.Background(), slog.LevelError))
+	logger.Info("message", "k", "v")
+	logger.Error("message", "err", errorz.Errorf("test error"))
+}
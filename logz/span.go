@@ -0,0 +1,118 @@
+package logz
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+	"github.com/ibrt/golang-inject-clock/clockz"
+)
+
+const (
+	sentryTraceContextKey = "trace"
+	spanErrorKey          = "error"
+)
+
+var (
+	_ Span = &spanImpl{}
+	_ Span = &noopSpanImpl{}
+
+	noopSpan = &noopSpanImpl{}
+)
+
+type spanImpl struct {
+	ctx  context.Context
+	logs *logsImpl
+	span *sentry.Span
+}
+
+// SetStatus sets the span status.
+func (s *spanImpl) SetStatus(status sentry.SpanStatus) {
+	s.span.Status = status
+}
+
+// SetTag sets a tag on the span, see T for limits.
+func (s *spanImpl) SetTag(k, v string) {
+	s.span.SetTag(checkTag(k, v))
+}
+
+// SetData sets data on the span.
+func (s *spanImpl) SetData(k string, v interface{}) {
+	s.span.Data[k] = v
+}
+
+// RecordError marks the span as failed and reports the error, linked to the span, it does nothing if err is nil.
+func (s *spanImpl) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.span.Status = errorToTransactionSpanStatus(err)
+	s.span.Data[spanErrorKey] = err.Error()
+
+	hub := sentry.GetHubFromContext(s.ctx).Clone()
+	hub.Scope().SetContext(sentryTraceContextKey, &sentry.TraceContext{
+		TraceID:      s.span.TraceID,
+		SpanID:       s.span.SpanID,
+		ParentSpanID: s.span.ParentSpanID,
+		Op:           s.span.Op,
+		Description:  s.span.Description,
+		Status:       s.span.Status,
+	})
+
+	s.logs.captureError(sentry.SetHubOnContext(s.ctx, hub), errorz.Wrap(err, errorz.SkipPackage()), Error)
+}
+
+// Finish finishes the span.
+func (s *spanImpl) Finish() {
+	s.span.EndTime = clockz.Get(s.ctx).Now()
+	s.span.Finish()
+
+	if s.logs.breadcrumbs {
+		sentry.GetHubFromContext(s.ctx).AddBreadcrumb(spanToSentryBreadcrumb(s.span), nil)
+	}
+}
+
+// FinishWithError finishes the span, recording the error if not nil or setting the status to OK otherwise.
+func (s *spanImpl) FinishWithError(err error) {
+	if err != nil {
+		s.RecordError(errorz.Wrap(err, errorz.SkipPackage()))
+	} else if s.span.Status == sentry.SpanStatusUndefined {
+		s.span.Status = sentry.SpanStatusOK
+	}
+
+	s.Finish()
+}
+
+type noopSpanImpl struct {
+}
+
+// SetStatus sets the span status.
+func (s *noopSpanImpl) SetStatus(_ sentry.SpanStatus) {
+	// nothing to do here
+}
+
+// SetTag sets a tag on the span, see T for limits.
+func (s *noopSpanImpl) SetTag(_, _ string) {
+	// nothing to do here
+}
+
+// SetData sets data on the span.
+func (s *noopSpanImpl) SetData(_ string, _ interface{}) {
+	// nothing to do here
+}
+
+// RecordError marks the span as failed and reports the error, linked to the span, it does nothing if err is nil.
+func (s *noopSpanImpl) RecordError(_ error) {
+	// nothing to do here
+}
+
+// Finish finishes the span.
+func (s *noopSpanImpl) Finish() {
+	// nothing to do here
+}
+
+// FinishWithError finishes the span, recording the error if not nil or setting the status to OK otherwise.
+func (s *noopSpanImpl) FinishWithError(_ error) {
+	// nothing to do here
+}
@@ -0,0 +1,57 @@
+package logz
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	defaultMaxStackDepth = 1024
+)
+
+var (
+	callersPool = sync.Pool{
+		New: func() interface{} {
+			callers := make([]uintptr, defaultMaxStackDepth)
+			return &callers
+		},
+	}
+)
+
+// StackCapture describes which Debug and Info entries capture the stack of their caller.
+type StackCapture string
+
+// Known stack capture modes.
+const (
+	StackCaptureOff    StackCapture = "off"
+	StackCaptureSentry StackCapture = "sentry"
+	StackCaptureAlways StackCapture = "always"
+)
+
+// isEnabled returns true if an entry should capture the stack, given whether it is at or above the Sentry level.
+func (s StackCapture) isEnabled(isSentryBound bool) bool {
+	switch s {
+	case StackCaptureOff:
+		return false
+	case StackCaptureSentry:
+		return isSentryBound
+	case StackCaptureAlways:
+		return true
+	default:
+		panic(errorz.Errorf("unknown stack capture: %v", errorz.A(s), errorz.SkipPackage()))
+	}
+}
+
+// captureCallers captures up to maxDepth callers in a pooled buffer, which must be returned using releaseCallers.
+func captureCallers(skipCallers, maxDepth int) (*[]uintptr, []uintptr) {
+	buf := callersPool.Get().(*[]uintptr)
+	return buf, (*buf)[:runtime.Callers(skipCallers+2, (*buf)[:maxDepth])]
+}
+
+func releaseCallers(buf *[]uintptr) {
+	if buf != nil {
+		callersPool.Put(buf)
+	}
+}
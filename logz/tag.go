@@ -0,0 +1,69 @@
+package logz
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+const (
+	maxTagKeyLength   = 32
+	maxTagValueLength = 200
+)
+
+var (
+	tagKeyInvalidRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.:-]`)
+)
+
+// tagValue marks error metadata holding a tag, which is moved to the Sentry event tags.
+type tagValue string
+
+// T is a shorthand for providing a tag to an entry. Tags are indexed by Sentry, unlike metadata.
+// Keys are limited to 32 characters among letters, digits, "_", ".", ":" and "-", other characters are replaced by "_".
+// Values are truncated to 200 characters.
+func T(k, v string) OptionFunc {
+	k, v = checkTag(k, v)
+
+	return func(e *entry) {
+		e.tags[k] = v
+	}
+}
+
+// ErrorTag is an errorz.Option which adds a tag used when the error is reported to Sentry.
+func ErrorTag(k, v string) errorz.OptionFunc {
+	k, v = checkTag(k, v)
+	return errorz.M(k, tagValue(v))
+}
+
+// checkTag sanitizes the tag key and value, as required by Sentry.
+func checkTag(k, v string) (string, string) {
+	return sanitizeTagKey(k), truncateTagValue(v)
+}
+
+// sanitizeTagKey replaces invalid characters and truncates the tag key, as required by Sentry.
+func sanitizeTagKey(k string) string {
+	if k == "" {
+		return "_"
+	}
+
+	k = tagKeyInvalidRegexp.ReplaceAllLiteralString(k, "_")
+
+	if len(k) <= maxTagKeyLength {
+		return k
+	}
+
+	return k[:maxTagKeyLength]
+}
+
+// truncateTagValue replaces newlines and truncates the tag value, as required by Sentry.
+func truncateTagValue(v string) string {
+	v = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(v)
+
+	if utf8.RuneCountInString(v) <= maxTagValueLength {
+		return v
+	}
+
+	return string([]rune(v)[:maxTagValueLength])
+}
@@ -0,0 +1,26 @@
+package logz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTag(t *testing.T) {
+	k, v := checkTag("a-Z_0.9:x", "line1\nline2\r\nline3")
+	require.Equal(t, "a-Z_0.9:x", k)
+	require.Equal(t, "line1 line2 line3", v)
+
+	_, v = checkTag("k", strings.Repeat("é", maxTagValueLength+1))
+	require.Equal(t, strings.Repeat("é", maxTagValueLength), v)
+
+	k, _ = checkTag("", "v")
+	require.Equal(t, "_", k)
+
+	k, _ = checkTag("invalid key/é", "v")
+	require.Equal(t, "invalid_key__", k)
+
+	k, _ = checkTag(strings.Repeat("k", maxTagKeyLength+1), "v")
+	require.Equal(t, strings.Repeat("k", maxTagKeyLength), k)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	sentry "github.com/getsentry/sentry-go"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,18 @@ func (mr *MockLogsMockRecorder) AddMetadata(ctx, k, v interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMetadata", reflect.TypeOf((*MockLogs)(nil).AddMetadata), ctx, k, v)
 }
 
+// AddTag mocks base method.
+func (m *MockLogs) AddTag(ctx context.Context, k, v string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddTag", ctx, k, v)
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockLogsMockRecorder) AddTag(ctx, k, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockLogs)(nil).AddTag), ctx, k, v)
+}
+
 // Debug mocks base method.
 func (m *MockLogs) Debug(ctx context.Context, skipCallers int, format string, options ...logz.Option) {
 	m.ctrl.T.Helper()
@@ -66,6 +79,20 @@ func (mr *MockLogsMockRecorder) Debug(ctx, skipCallers, format interface{}, opti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockLogs)(nil).Debug), varargs...)
 }
 
+// Enabled mocks base method.
+func (m *MockLogs) Enabled(ctx context.Context, level logz.Level) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx, level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockLogsMockRecorder) Enabled(ctx, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockLogs)(nil).Enabled), ctx, level)
+}
+
 // Error mocks base method.
 func (m *MockLogs) Error(ctx context.Context, err error) {
 	m.ctrl.T.Helper()
@@ -78,6 +105,60 @@ func (mr *MockLogsMockRecorder) Error(ctx, err interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLogs)(nil).Error), ctx, err)
 }
 
+// Fatal mocks base method.
+func (m *MockLogs) Fatal(ctx context.Context, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fatal", ctx, err)
+}
+
+// Fatal indicates an expected call of Fatal.
+func (mr *MockLogsMockRecorder) Fatal(ctx, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockLogs)(nil).Fatal), ctx, err)
+}
+
+// Flush mocks base method.
+func (m *MockLogs) Flush(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockLogsMockRecorder) Flush(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockLogs)(nil).Flush), ctx)
+}
+
+// GetAsyncStats mocks base method.
+func (m *MockLogs) GetAsyncStats(ctx context.Context) *logz.AsyncStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAsyncStats", ctx)
+	ret0, _ := ret[0].(*logz.AsyncStats)
+	return ret0
+}
+
+// GetAsyncStats indicates an expected call of GetAsyncStats.
+func (mr *MockLogsMockRecorder) GetAsyncStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAsyncStats", reflect.TypeOf((*MockLogs)(nil).GetAsyncStats), ctx)
+}
+
+// GetLevels mocks base method.
+func (m *MockLogs) GetLevels(ctx context.Context) *logz.Levels {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLevels", ctx)
+	ret0, _ := ret[0].(*logz.Levels)
+	return ret0
+}
+
+// GetLevels indicates an expected call of GetLevels.
+func (mr *MockLogsMockRecorder) GetLevels(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLevels", reflect.TypeOf((*MockLogs)(nil).GetLevels), ctx)
+}
+
 // Info mocks base method.
 func (m *MockLogs) Info(ctx context.Context, skipCallers int, format string, options ...logz.Option) {
 	m.ctrl.T.Helper()
@@ -95,6 +176,46 @@ func (mr *MockLogsMockRecorder) Info(ctx, skipCallers, format interface{}, optio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLogs)(nil).Info), varargs...)
 }
 
+// Named mocks base method.
+func (m *MockLogs) Named(ctx context.Context, name string) context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Named", ctx, name)
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Named indicates an expected call of Named.
+func (mr *MockLogsMockRecorder) Named(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Named", reflect.TypeOf((*MockLogs)(nil).Named), ctx, name)
+}
+
+// ResetLevels mocks base method.
+func (m *MockLogs) ResetLevels(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLevels", ctx)
+}
+
+// ResetLevels indicates an expected call of ResetLevels.
+func (mr *MockLogsMockRecorder) ResetLevels(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLevels", reflect.TypeOf((*MockLogs)(nil).ResetLevels), ctx)
+}
+
+// SetLevels mocks base method.
+func (m *MockLogs) SetLevels(ctx context.Context, levels *logz.Levels, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevels", ctx, levels, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLevels indicates an expected call of SetLevels.
+func (mr *MockLogsMockRecorder) SetLevels(ctx, levels, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevels", reflect.TypeOf((*MockLogs)(nil).SetLevels), ctx, levels, ttl)
+}
+
 // SetUser mocks base method.
 func (m *MockLogs) SetUser(ctx context.Context, user *logz.User) {
 	m.ctrl.T.Helper()
@@ -107,6 +228,38 @@ func (mr *MockLogsMockRecorder) SetUser(ctx, user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUser", reflect.TypeOf((*MockLogs)(nil).SetUser), ctx, user)
 }
 
+// StartSpan mocks base method.
+func (m *MockLogs) StartSpan(ctx context.Context, op, desc string) (context.Context, logz.Span) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSpan", ctx, op, desc)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(logz.Span)
+	return ret0, ret1
+}
+
+// StartSpan indicates an expected call of StartSpan.
+func (mr *MockLogsMockRecorder) StartSpan(ctx, op, desc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSpan", reflect.TypeOf((*MockLogs)(nil).StartSpan), ctx, op, desc)
+}
+
+// Trace mocks base method.
+func (m *MockLogs) Trace(ctx context.Context, skipCallers int, format string, options ...logz.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, skipCallers, format}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Trace", varargs...)
+}
+
+// Trace indicates an expected call of Trace.
+func (mr *MockLogsMockRecorder) Trace(ctx, skipCallers, format interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, skipCallers, format}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trace", reflect.TypeOf((*MockLogs)(nil).Trace), varargs...)
+}
+
 // TraceHTTPRequestServer mocks base method.
 func (m *MockLogs) TraceHTTPRequestServer(ctx context.Context, req *http.Request, reqBody []byte) (context.Context, func()) {
 	m.ctrl.T.Helper()
@@ -152,6 +305,26 @@ func (mr *MockLogsMockRecorder) TraceSpan(ctx, op, desc interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceSpan", reflect.TypeOf((*MockLogs)(nil).TraceSpan), ctx, op, desc)
 }
 
+// TraceTransaction mocks base method.
+func (m *MockLogs) TraceTransaction(ctx context.Context, op, name string, options ...logz.TransactionOption) (context.Context, func(error)) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, op, name}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TraceTransaction", varargs...)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func(error))
+	return ret0, ret1
+}
+
+// TraceTransaction indicates an expected call of TraceTransaction.
+func (mr *MockLogsMockRecorder) TraceTransaction(ctx, op, name interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, op, name}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceTransaction", reflect.TypeOf((*MockLogs)(nil).TraceTransaction), varargs...)
+}
+
 // Warning mocks base method.
 func (m *MockLogs) Warning(ctx context.Context, err error) {
 	m.ctrl.T.Helper()
@@ -164,6 +337,120 @@ func (mr *MockLogsMockRecorder) Warning(ctx, err interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warning", reflect.TypeOf((*MockLogs)(nil).Warning), ctx, err)
 }
 
+// With mocks base method.
+func (m *MockLogs) With(ctx context.Context, options ...logz.Option) context.Context {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockLogsMockRecorder) With(ctx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockLogs)(nil).With), varargs...)
+}
+
+// MockSpan is a mock of Span interface.
+type MockSpan struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpanMockRecorder
+}
+
+// MockSpanMockRecorder is the mock recorder for MockSpan.
+type MockSpanMockRecorder struct {
+	mock *MockSpan
+}
+
+// NewMockSpan creates a new mock instance.
+func NewMockSpan(ctrl *gomock.Controller) *MockSpan {
+	mock := &MockSpan{ctrl: ctrl}
+	mock.recorder = &MockSpanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpan) EXPECT() *MockSpanMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockSpan) Finish() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Finish")
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockSpanMockRecorder) Finish() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockSpan)(nil).Finish))
+}
+
+// FinishWithError mocks base method.
+func (m *MockSpan) FinishWithError(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FinishWithError", err)
+}
+
+// FinishWithError indicates an expected call of FinishWithError.
+func (mr *MockSpanMockRecorder) FinishWithError(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishWithError", reflect.TypeOf((*MockSpan)(nil).FinishWithError), err)
+}
+
+// RecordError mocks base method.
+func (m *MockSpan) RecordError(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordError", err)
+}
+
+// RecordError indicates an expected call of RecordError.
+func (mr *MockSpanMockRecorder) RecordError(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordError", reflect.TypeOf((*MockSpan)(nil).RecordError), err)
+}
+
+// SetData mocks base method.
+func (m *MockSpan) SetData(k string, v interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetData", k, v)
+}
+
+// SetData indicates an expected call of SetData.
+func (mr *MockSpanMockRecorder) SetData(k, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetData", reflect.TypeOf((*MockSpan)(nil).SetData), k, v)
+}
+
+// SetStatus mocks base method.
+func (m *MockSpan) SetStatus(status sentry.SpanStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStatus", status)
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockSpanMockRecorder) SetStatus(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockSpan)(nil).SetStatus), status)
+}
+
+// SetTag mocks base method.
+func (m *MockSpan) SetTag(k, v string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTag", k, v)
+}
+
+// SetTag indicates an expected call of SetTag.
+func (mr *MockSpanMockRecorder) SetTag(k, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTag", reflect.TypeOf((*MockSpan)(nil).SetTag), k, v)
+}
+
 // MockContextLogs is a mock of ContextLogs interface.
 type MockContextLogs struct {
 	ctrl     *gomock.Controller
@@ -199,6 +486,18 @@ func (mr *MockContextLogsMockRecorder) AddMetadata(k, v interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMetadata", reflect.TypeOf((*MockContextLogs)(nil).AddMetadata), k, v)
 }
 
+// AddTag mocks base method.
+func (m *MockContextLogs) AddTag(k, v string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddTag", k, v)
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockContextLogsMockRecorder) AddTag(k, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockContextLogs)(nil).AddTag), k, v)
+}
+
 // Debug mocks base method.
 func (m *MockContextLogs) Debug(format string, options ...logz.Option) {
 	m.ctrl.T.Helper()
@@ -216,6 +515,20 @@ func (mr *MockContextLogsMockRecorder) Debug(format interface{}, options ...inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockContextLogs)(nil).Debug), varargs...)
 }
 
+// Enabled mocks base method.
+func (m *MockContextLogs) Enabled(level logz.Level) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockContextLogsMockRecorder) Enabled(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockContextLogs)(nil).Enabled), level)
+}
+
 // Error mocks base method.
 func (m *MockContextLogs) Error(err error) {
 	m.ctrl.T.Helper()
@@ -228,6 +541,60 @@ func (mr *MockContextLogsMockRecorder) Error(err interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockContextLogs)(nil).Error), err)
 }
 
+// Fatal mocks base method.
+func (m *MockContextLogs) Fatal(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fatal", err)
+}
+
+// Fatal indicates an expected call of Fatal.
+func (mr *MockContextLogsMockRecorder) Fatal(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockContextLogs)(nil).Fatal), err)
+}
+
+// Flush mocks base method.
+func (m *MockContextLogs) Flush() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockContextLogsMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockContextLogs)(nil).Flush))
+}
+
+// GetAsyncStats mocks base method.
+func (m *MockContextLogs) GetAsyncStats() *logz.AsyncStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAsyncStats")
+	ret0, _ := ret[0].(*logz.AsyncStats)
+	return ret0
+}
+
+// GetAsyncStats indicates an expected call of GetAsyncStats.
+func (mr *MockContextLogsMockRecorder) GetAsyncStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAsyncStats", reflect.TypeOf((*MockContextLogs)(nil).GetAsyncStats))
+}
+
+// GetLevels mocks base method.
+func (m *MockContextLogs) GetLevels() *logz.Levels {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLevels")
+	ret0, _ := ret[0].(*logz.Levels)
+	return ret0
+}
+
+// GetLevels indicates an expected call of GetLevels.
+func (mr *MockContextLogsMockRecorder) GetLevels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLevels", reflect.TypeOf((*MockContextLogs)(nil).GetLevels))
+}
+
 // Info mocks base method.
 func (m *MockContextLogs) Info(format string, options ...logz.Option) {
 	m.ctrl.T.Helper()
@@ -245,6 +612,46 @@ func (mr *MockContextLogsMockRecorder) Info(format interface{}, options ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockContextLogs)(nil).Info), varargs...)
 }
 
+// Named mocks base method.
+func (m *MockContextLogs) Named(name string) logz.ContextLogs {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Named", name)
+	ret0, _ := ret[0].(logz.ContextLogs)
+	return ret0
+}
+
+// Named indicates an expected call of Named.
+func (mr *MockContextLogsMockRecorder) Named(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Named", reflect.TypeOf((*MockContextLogs)(nil).Named), name)
+}
+
+// ResetLevels mocks base method.
+func (m *MockContextLogs) ResetLevels() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLevels")
+}
+
+// ResetLevels indicates an expected call of ResetLevels.
+func (mr *MockContextLogsMockRecorder) ResetLevels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLevels", reflect.TypeOf((*MockContextLogs)(nil).ResetLevels))
+}
+
+// SetLevels mocks base method.
+func (m *MockContextLogs) SetLevels(levels *logz.Levels, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevels", levels, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLevels indicates an expected call of SetLevels.
+func (mr *MockContextLogsMockRecorder) SetLevels(levels, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevels", reflect.TypeOf((*MockContextLogs)(nil).SetLevels), levels, ttl)
+}
+
 // SetUser mocks base method.
 func (m *MockContextLogs) SetUser(user *logz.User) {
 	m.ctrl.T.Helper()
@@ -257,6 +664,38 @@ func (mr *MockContextLogsMockRecorder) SetUser(user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUser", reflect.TypeOf((*MockContextLogs)(nil).SetUser), user)
 }
 
+// StartSpan mocks base method.
+func (m *MockContextLogs) StartSpan(op, desc string) (context.Context, logz.Span) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSpan", op, desc)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(logz.Span)
+	return ret0, ret1
+}
+
+// StartSpan indicates an expected call of StartSpan.
+func (mr *MockContextLogsMockRecorder) StartSpan(op, desc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSpan", reflect.TypeOf((*MockContextLogs)(nil).StartSpan), op, desc)
+}
+
+// Trace mocks base method.
+func (m *MockContextLogs) Trace(format string, options ...logz.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Trace", varargs...)
+}
+
+// Trace indicates an expected call of Trace.
+func (mr *MockContextLogsMockRecorder) Trace(format interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trace", reflect.TypeOf((*MockContextLogs)(nil).Trace), varargs...)
+}
+
 // TraceHTTPRequestServer mocks base method.
 func (m *MockContextLogs) TraceHTTPRequestServer(req *http.Request, reqBody []byte) (context.Context, func()) {
 	m.ctrl.T.Helper()
@@ -302,6 +741,26 @@ func (mr *MockContextLogsMockRecorder) TraceSpan(op, desc interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceSpan", reflect.TypeOf((*MockContextLogs)(nil).TraceSpan), op, desc)
 }
 
+// TraceTransaction mocks base method.
+func (m *MockContextLogs) TraceTransaction(op, name string, options ...logz.TransactionOption) (context.Context, func(error)) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{op, name}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TraceTransaction", varargs...)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func(error))
+	return ret0, ret1
+}
+
+// TraceTransaction indicates an expected call of TraceTransaction.
+func (mr *MockContextLogsMockRecorder) TraceTransaction(op, name interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{op, name}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceTransaction", reflect.TypeOf((*MockContextLogs)(nil).TraceTransaction), varargs...)
+}
+
 // Warning mocks base method.
 func (m *MockContextLogs) Warning(err error) {
 	m.ctrl.T.Helper()
@@ -313,3 +772,21 @@ func (mr *MockContextLogsMockRecorder) Warning(err interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warning", reflect.TypeOf((*MockContextLogs)(nil).Warning), err)
 }
+
+// With mocks base method.
+func (m *MockContextLogs) With(options ...logz.Option) logz.ContextLogs {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(logz.ContextLogs)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockContextLogsMockRecorder) With(options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockContextLogs)(nil).With), options...)
+}
@@ -1,22 +1,84 @@
 package logz
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
+	"net/http"
 	"regexp"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
 )
 
 const (
 	sentryTraceHeader         = "sentry-trace"
+	sentryTransactionType     = "transaction"
 	sentryMaxRequestBodyBytes = 10 * 1024
 	logsRequestExtraKey       = "golang-inject-logs-request"
+	logsSpanContextsKey       = "golang-inject-logs-span"
+	defaultTraceIDField       = "trace_id"
+	defaultSpanIDField        = "span_id"
+	defaultSampledField       = "sampled"
 )
 
 var (
 	sentryTraceRegexp = regexp.MustCompile(`^([[:xdigit:]]{32})-([[:xdigit:]]{16})(?:-([01]))?$`)
 )
 
+// TraceFields describes the names of the output log fields which carry the current span, empty names are omitted.
+type TraceFields struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+	Sampled string `json:"sampled"`
+}
+
+// NewDefaultTraceFields returns the default TraceFields: "trace_id", "span_id" and "sampled".
+func NewDefaultTraceFields() *TraceFields {
+	return &TraceFields{
+		TraceID: defaultTraceIDField,
+		SpanID:  defaultSpanIDField,
+		Sampled: defaultSampledField,
+	}
+}
+
+// toLogrusFields returns the logrus fields for the given trace context.
+func (f *TraceFields) toLogrusFields(tc *traceContext) logrus.Fields {
+	fields := logrus.Fields{}
+
+	if f.TraceID != "" {
+		fields[f.TraceID] = tc.traceID.String()
+	}
+
+	if f.SpanID != "" {
+		fields[f.SpanID] = tc.spanID.String()
+	}
+
+	if f.Sampled != "" {
+		fields[f.Sampled] = tc.sampled.Bool()
+	}
+
+	return fields
+}
+
+// setEventSpan attaches the IDs of the current span to the event if there is one, so that they are output with it.
+func setEventSpan(ctx context.Context, event *sentry.Event) {
+	if span := getSpan(ctx); span != nil {
+		tc := &traceContext{}
+		tc.setIDs(span.TraceID, span.SpanID, span.Sampled)
+		event.Contexts[logsSpanContextsKey] = tc
+	}
+}
+
+// popEventSpan detaches the IDs of the current span from the event, if attached by setEventSpan.
+func popEventSpan(event *sentry.Event) *traceContext {
+	tc, ok := event.Contexts[logsSpanContextsKey].(*traceContext)
+	if ok {
+		delete(event.Contexts, logsSpanContextsKey)
+	}
+	return tc
+}
+
 func traceBeforeSend(event *sentry.Event) *sentry.Event {
 	if event == nil {
 		return nil
@@ -33,17 +95,45 @@ func traceBeforeSend(event *sentry.Event) *sentry.Event {
 	return event
 }
 
-func newTraceSpanOption(headers map[string]string) sentry.SpanOption {
-	return func(span *sentry.Span) {
-		if headers != nil {
-			if sentryTrace := headers[sentryTraceHeader]; sentryTrace != "" {
-				if traceID, parentSpanID, sampled, ok := parseSentryTraceHeader(sentryTrace); ok {
-					span.TraceID = traceID
-					span.ParentSpanID = parentSpanID
-					span.Sampled = sampled
-				}
-			}
-		}
+func httpStatusToSpanStatus(statusCode int) sentry.SpanStatus {
+	switch {
+	case statusCode < http.StatusBadRequest:
+		return sentry.SpanStatusOK
+	case statusCode == http.StatusUnauthorized:
+		return sentry.SpanStatusUnauthenticated
+	case statusCode == http.StatusForbidden:
+		return sentry.SpanStatusPermissionDenied
+	case statusCode == http.StatusNotFound:
+		return sentry.SpanStatusNotFound
+	case statusCode == http.StatusConflict:
+		return sentry.SpanStatusAlreadyExists
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return sentry.SpanStatusFailedPrecondition
+	case statusCode == http.StatusTooManyRequests:
+		return sentry.SpanStatusResourceExhausted
+	case statusCode == 499:
+		return sentry.SpanStatusCanceled
+	case statusCode < http.StatusInternalServerError:
+		return sentry.SpanStatusInvalidArgument
+	case statusCode == http.StatusNotImplemented:
+		return sentry.SpanStatusUnimplemented
+	case statusCode == http.StatusServiceUnavailable:
+		return sentry.SpanStatusUnavailable
+	case statusCode == http.StatusGatewayTimeout:
+		return sentry.SpanStatusDeadlineExceeded
+	default:
+		return sentry.SpanStatusInternalError
+	}
+}
+
+func errorToSpanStatus(err error) sentry.SpanStatus {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return sentry.SpanStatusDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return sentry.SpanStatusCanceled
+	default:
+		return sentry.SpanStatusUnknown
 	}
 }
 
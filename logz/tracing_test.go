@@ -1,6 +1,9 @@
 package logz
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -28,6 +31,30 @@ func TestParseSentryTraceHeader(t *testing.T) {
 	require.False(t, sampled.Bool())
 }
 
+func TestHTTPStatusToSpanStatus(t *testing.T) {
+	require.Equal(t, sentry.SpanStatusOK, httpStatusToSpanStatus(http.StatusOK))
+	require.Equal(t, sentry.SpanStatusOK, httpStatusToSpanStatus(http.StatusFound))
+	require.Equal(t, sentry.SpanStatusInvalidArgument, httpStatusToSpanStatus(http.StatusBadRequest))
+	require.Equal(t, sentry.SpanStatusUnauthenticated, httpStatusToSpanStatus(http.StatusUnauthorized))
+	require.Equal(t, sentry.SpanStatusPermissionDenied, httpStatusToSpanStatus(http.StatusForbidden))
+	require.Equal(t, sentry.SpanStatusNotFound, httpStatusToSpanStatus(http.StatusNotFound))
+	require.Equal(t, sentry.SpanStatusAlreadyExists, httpStatusToSpanStatus(http.StatusConflict))
+	require.Equal(t, sentry.SpanStatusFailedPrecondition, httpStatusToSpanStatus(http.StatusRequestEntityTooLarge))
+	require.Equal(t, sentry.SpanStatusResourceExhausted, httpStatusToSpanStatus(http.StatusTooManyRequests))
+	require.Equal(t, sentry.SpanStatusCanceled, httpStatusToSpanStatus(499))
+	require.Equal(t, sentry.SpanStatusInternalError, httpStatusToSpanStatus(http.StatusInternalServerError))
+	require.Equal(t, sentry.SpanStatusUnimplemented, httpStatusToSpanStatus(http.StatusNotImplemented))
+	require.Equal(t, sentry.SpanStatusInternalError, httpStatusToSpanStatus(http.StatusBadGateway))
+	require.Equal(t, sentry.SpanStatusUnavailable, httpStatusToSpanStatus(http.StatusServiceUnavailable))
+	require.Equal(t, sentry.SpanStatusDeadlineExceeded, httpStatusToSpanStatus(http.StatusGatewayTimeout))
+}
+
+func TestErrorToSpanStatus(t *testing.T) {
+	require.Equal(t, sentry.SpanStatusDeadlineExceeded, errorToSpanStatus(context.DeadlineExceeded))
+	require.Equal(t, sentry.SpanStatusCanceled, errorToSpanStatus(fmt.Errorf("wrapped: %w", context.Canceled)))
+	require.Equal(t, sentry.SpanStatusUnknown, errorToSpanStatus(fmt.Errorf("error")))
+}
+
 func TestTraceBeforeSend(t *testing.T) {
 	require.Nil(t, traceBeforeSend(nil))
 	require.Equal(t, sentry.NewEvent(), traceBeforeSend(sentry.NewEvent()))
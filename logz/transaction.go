@@ -0,0 +1,54 @@
+package logz
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ibrt/golang-errors/errorz"
+)
+
+var (
+	_ context.Context = &rootContext{}
+)
+
+// TransactionOption describes an option for TraceTransaction.
+type TransactionOption func(c *transactionConfig)
+
+type transactionConfig struct {
+	carrier map[string]string
+}
+
+// ContinueFrom continues the trace propagated in the given carrier (e.g. message headers), using the configured
+// propagators. Keys are matched case-insensitively.
+func ContinueFrom(carrier map[string]string) TransactionOption {
+	return func(c *transactionConfig) {
+		c.carrier = carrier
+	}
+}
+
+// rootContext carries the values and cancellation of its parent, hiding its spans so that new spans start a transaction.
+type rootContext struct {
+	context.Context
+}
+
+// Value implements the context.Context interface.
+func (c *rootContext) Value(key interface{}) interface{} {
+	return hideSpan(c.Context.Value(key))
+}
+
+// errorToTransactionSpanStatus converts the error a transaction finished with to a span status.
+func errorToTransactionSpanStatus(err error) sentry.SpanStatus {
+	if err == nil {
+		return sentry.SpanStatusOK
+	}
+
+	if status := errorz.GetStatus(err); status != 0 {
+		return httpStatusToSpanStatus(status.Int())
+	}
+
+	if status := errorToSpanStatus(errorz.Unwrap(err)); status != sentry.SpanStatusUnknown {
+		return status
+	}
+
+	return sentry.SpanStatusInternalError
+}
@@ -8,24 +8,51 @@ import (
 )
 
 type logsTransport struct {
-	logrusLogger *logrus.Logger
-	transport    sentry.Transport
+	logrusLogger          *logrus.Logger
+	levels                *levelsController
+	redactor              *redactor
+	beforeSend            BeforeSendFunc
+	beforeSendTransaction BeforeSendFunc
+	outputDroppedEvents   bool
+	traceFields           *TraceFields
+	transport             sentry.Transport
+	async                 *asyncQueue
 }
 
-func newLogsTransport(logrusLogger *logrus.Logger, transport sentry.Transport) *logsTransport {
+func newLogsTransport(cfg *Config, logrusLogger *logrus.Logger, levels *levelsController) *logsTransport {
+	transport := cfg.SentryTransport
 	if transport == nil {
 		transport = sentry.NewHTTPTransport()
 	}
 
-	return &logsTransport{
-		logrusLogger: logrusLogger,
-		transport:    transport,
+	traceFields := cfg.TraceFields
+	if traceFields == nil {
+		traceFields = NewDefaultTraceFields()
 	}
+
+	t := &logsTransport{
+		logrusLogger:          logrusLogger,
+		levels:                levels,
+		redactor:              newRedactor(cfg.Redaction),
+		beforeSend:            cfg.BeforeSend,
+		beforeSendTransaction: cfg.BeforeSendTransaction,
+		outputDroppedEvents:   cfg.OutputDroppedEvents,
+		traceFields:           traceFields,
+		transport:             transport,
+	}
+
+	t.async = newAsyncQueue(cfg.Async, t.sendEvent)
+	return t
 }
 
-// Flush implements the sentry.Transport interface.
+// Flush implements the sentry.Transport interface, in async mode it waits for the queue to be processed first.
 func (t *logsTransport) Flush(timeout time.Duration) bool {
-	return t.transport.Flush(timeout)
+	start := time.Now()
+	if !t.async.flush(timeout) {
+		return false
+	}
+
+	return t.transport.Flush(timeout - time.Since(start))
 }
 
 // Configure implements the sentry.Transport interface.
@@ -33,9 +60,71 @@ func (t *logsTransport) Configure(options sentry.ClientOptions) {
 	t.transport.Configure(options)
 }
 
-// SendEvent implements the sentry.Transport interface.
+// SendEvent implements the sentry.Transport interface, in async mode it queues the event.
 func (t *logsTransport) SendEvent(event *sentry.Event) {
+	if !t.async.enqueue(event) {
+		t.sendEvent(event)
+	}
+}
+
+// sendEvent redacts and outputs the event, forwarding it to Sentry if it is a transaction or at or above the Sentry level.
+func (t *logsTransport) sendEvent(event *sentry.Event) {
+	t.redactor.redactEvent(event)
+	t.output(event, popEventSpan(event))
+
+	level := levelFromSentry(event.Level)
+	logger := event.Tags[loggerTagKey]
+
+	if event.Type == sentryTransactionType || (level != Trace && level.Enabled(t.levels.get().getSentryLevel(logger))) {
+		t.transport.SendEvent(event)
+	}
+}
+
+// close stops the async workers, if any, once queued events are processed.
+func (t *logsTransport) close() {
+	t.async.close()
+}
+
+// processEvent is a sentry.EventProcessor which chains traceBeforeSend with the configured hooks.
+func (t *logsTransport) processEvent(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
 	event = traceBeforeSend(event)
+	if event == nil {
+		return nil
+	}
+
+	beforeSend := t.beforeSend
+	if event.Type == sentryTransactionType {
+		beforeSend = t.beforeSendTransaction
+	}
+
+	if beforeSend == nil {
+		return event
+	}
+
+	if hint == nil {
+		hint = &sentry.EventHint{}
+	}
+
+	if processedEvent := beforeSend(event, hint); processedEvent != nil {
+		return processedEvent
+	}
+
+	if t.outputDroppedEvents {
+		t.redactor.redactEvent(event)
+		t.output(event, popEventSpan(event))
+	}
+
+	return nil
+}
+
+// output writes the event to logrus if at or above the output level, it expects a redacted event.
+// If tc is not nil, the IDs of the span the event was logged in are added as fields.
+func (t *logsTransport) output(event *sentry.Event, tc *traceContext) {
+	level := levelFromSentry(event.Level)
+
+	if !level.Enabled(t.levels.get().getOutputLevel(event.Tags[loggerTagKey])) {
+		return
+	}
 
 	logrusEntry := t.logrusLogger.
 		WithTime(event.Timestamp).
@@ -45,11 +134,18 @@ func (t *logsTransport) SendEvent(event *sentry.Event) {
 		logrusEntry = logrusEntry.WithField("uid", event.User.ID)
 	}
 
+	for k, v := range event.Tags {
+		logrusEntry = logrusEntry.WithField(k, v)
+	}
+
+	if tc != nil {
+		logrusEntry = logrusEntry.WithFields(t.traceFields.toLogrusFields(tc))
+	}
+
 	message := event.Message
 	if len(event.Exception) > 0 {
 		message = event.Exception[0].Value
 	}
 
-	logrusEntry.Log(levelFromSentry(event.Level).toLogrus(), message)
-	t.transport.SendEvent(event)
+	logrusEntry.Log(level.toLogrus(), message)
 }
@@ -9,5 +9,5 @@ go build -v ./...
 diff -u <(echo -n) <(gofmt -d ./)
 go run golang.org/x/lint/golint@latest -set_exit_status ./...
 go vet ./...
-go run honnef.co/go/tools/cmd/staticcheck@release.2021.1 ./...
+go run honnef.co/go/tools/cmd/staticcheck@2023.1.7 ./...
 go test -v -race -failfast -shuffle=on -covermode=atomic -coverprofile=coverage.txt ./...
\ No newline at end of file